@@ -0,0 +1,134 @@
+package flagenum
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CompleteCommand is the hidden first argument that switches the FlagSetExt.Parse to the runtime completion mode.
+const CompleteCommand = "__complete"
+
+// ErrCompletion is returned by the FlagSetExt.Parse when the runtime completion is requested and done.
+var ErrCompletion = errors.New("flag: completion requested")
+
+// CompletionDirective instructs a shell how to handle the completion candidates.
+type CompletionDirective int
+
+const (
+	// CompleteDefault lets the shell complete file names if there are no candidates.
+	CompleteDefault CompletionDirective = 0
+	// CompleteError reports that the completion failed.
+	CompleteError CompletionDirective = 1 << (iota - 1)
+	// CompleteNoSpace asks the shell not to add a space after the completed candidate.
+	CompleteNoSpace
+	// CompleteNoFileComp asks the shell not to complete file names if there are no candidates.
+	CompleteNoFileComp
+)
+
+// Complete writes the completion candidates of the last argument, the word being completed, one per line.
+// A candidate can be followed by a tab character and a description.
+// The last line is the completion directive, the colon followed by the CompletionDirective number.
+// The candidates of an enum flag value are its allowed values, or ones returned by the Completer option function.
+// Values already given to a multiple flag are excluded, they are compared by the flag matching policy.
+// The last element of a value divided by the flag separator is completed, the preceding elements are excluded too.
+func (f *FlagSetExt) Complete(w io.Writer, args []string) error {
+	candidates, directive := f.complete(args)
+	out := strings.Builder{}
+	for _, c := range candidates {
+		out.WriteString(c.value)
+		if len(c.description) > 0 {
+			out.WriteString("\t" + c.description)
+		}
+		out.WriteString("\n")
+	}
+	fmt.Fprintf(&out, ":%d\n", directive)
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func (f *FlagSetExt) complete(args []string) ([]valueDescription, CompletionDirective) {
+	toComplete := ""
+	if len(args) > 0 {
+		toComplete, args = args[len(args)-1], args[:len(args)-1]
+	}
+	var (
+		given   = map[string][]string{}
+		pending *flag.Flag
+	)
+	for _, arg := range args {
+		if pending != nil {
+			given[pending.Name] = append(given[pending.Name], arg)
+			pending = nil
+			continue
+		}
+		if arg == "--" {
+			return nil, CompleteDefault
+		}
+		fl, value, hasValue := f.lookupArg(arg)
+		if fl == nil {
+			continue
+		}
+		if hasValue {
+			given[fl.Name] = append(given[fl.Name], value)
+		} else if takesValue(fl) {
+			pending = fl
+		}
+	}
+	if pending != nil {
+		return f.completeValues(pending, "", toComplete, given[pending.Name])
+	}
+	if !strings.HasPrefix(toComplete, "-") {
+		return nil, CompleteDefault
+	}
+	if fl, value, hasValue := f.lookupArg(toComplete); fl != nil && hasValue {
+		prefix := strings.TrimSuffix(toComplete, value)
+		return f.completeValues(fl, prefix, value, given[fl.Name])
+	}
+	dashes := "-"
+	if strings.HasPrefix(toComplete, "--") {
+		dashes = "--"
+	}
+	var candidates []valueDescription
+	f.VisitAll(func(fl *flag.Flag) {
+		if name := dashes + fl.Name; strings.HasPrefix(name, toComplete) {
+			usage := fl.Usage
+			if v, ok := fl.Value.(enumValue); ok {
+				usage = v.baseUsage()
+			}
+			usage, _, _ = strings.Cut(usage, "\n")
+			candidates = append(candidates, valueDescription{value: name, description: usage})
+		}
+	})
+	return candidates, CompleteNoFileComp
+}
+
+func (f *FlagSetExt) completeValues(fl *flag.Flag, prefix, toComplete string, given []string) ([]valueDescription, CompletionDirective) {
+	v, ok := fl.Value.(enumValue)
+	if !ok {
+		return nil, CompleteDefault
+	}
+	valuePrefix, candidates := v.completeValues(toComplete, given)
+	for i := range candidates {
+		candidates[i].value = prefix + valuePrefix + candidates[i].value
+	}
+	return candidates, CompleteNoFileComp
+}
+
+// lookupArg finds the flag of the argument like -name or --name=value.
+func (f *FlagSetExt) lookupArg(arg string) (fl *flag.Flag, value string, hasValue bool) {
+	name := strings.TrimPrefix(arg, "-")
+	if name == arg {
+		return nil, "", false
+	}
+	name = strings.TrimPrefix(name, "-")
+	name, value, hasValue = strings.Cut(name, "=")
+	return f.Lookup(name), value, hasValue
+}
+
+func takesValue(fl *flag.Flag) bool {
+	b, ok := fl.Value.(interface{ IsBoolFlag() bool })
+	return !ok || !b.IsBoolFlag()
+}
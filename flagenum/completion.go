@@ -0,0 +1,328 @@
+package flagenum
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Completion writes the shell completion script of the command-line flags by the CommandLine.Completion.
+func Completion(w io.Writer, shell string) error {
+	return CommandLine.Completion(w, shell)
+}
+
+// Completion writes a completion script of the flag set for the shell: bash, zsh or fish.
+// The script completes flag names and, after an enum flag, its allowed values, described ones where the shell supports it.
+// Values already given to a multiple flag are excluded from the suggestions, but the script compares whole arguments
+// exactly: it doesn't split a value by the flag separator and ignores the matching policy, see the Separator and Matching.
+// The runtime completion of the FlagSetExt.Complete handles both, a script can call the command with the CompleteCommand
+// argument to take such candidates.
+// The completed command is the flag set name.
+func (f *FlagSetExt) Completion(w io.Writer, shell string) error {
+	command := filepath.Base(f.Name())
+	flags := f.completionFlags()
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion(command, flags)
+	case "zsh":
+		script = zshCompletion(command, flags)
+	case "fish":
+		script = fishCompletion(command, flags)
+	default:
+		return fmt.Errorf("unsupported shell %q", shell)
+	}
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+type completionFlag struct {
+	name       string
+	usage      string
+	takesValue bool
+	multiple   bool
+	values     []valueDescription
+}
+
+func (f *FlagSetExt) completionFlags() []completionFlag {
+	var flags []completionFlag
+	f.VisitAll(func(fl *flag.Flag) {
+		cf := completionFlag{name: fl.Name, usage: fl.Usage, takesValue: takesValue(fl)}
+		if v, ok := fl.Value.(enumValue); ok {
+			cf.usage, cf.multiple, cf.values = v.baseUsage(), v.IsMultiple(), v.valueRows()
+		} else {
+			_, cf.usage = flag.UnquoteUsage(fl)
+		}
+		cf.usage, _, _ = strings.Cut(cf.usage, "\n")
+		flags = append(flags, cf)
+	})
+	return flags
+}
+
+func bashCompletion(command string, flags []completionFlag) string {
+	fn := "_" + identifier(command) + "_complete"
+	script := strings.Builder{}
+	fmt.Fprintf(&script, "# bash completion for %s\n\n", command)
+	fmt.Fprintf(&script, "%s() {\n", fn)
+	script.WriteString(`    local cur="${COMP_WORDS[COMP_CWORD]}" prev="" last=$((COMP_CWORD - 1))
+    if [ "$COMP_CWORD" -gt 0 ]; then
+        prev="${COMP_WORDS[COMP_CWORD-1]}"
+    fi
+    if [ "$cur" = "=" ]; then
+        cur=""
+    elif [ "$prev" = "=" ] && [ "$COMP_CWORD" -gt 1 ]; then
+        last=$((COMP_CWORD - 2))
+        prev="${COMP_WORDS[last]}"
+    fi
+    local flag="" values="" multiple=0
+    case "$prev" in
+    -*)
+        flag="${prev#-}"
+        flag="${flag#-}"
+        ;;
+    esac
+    case "$flag" in
+`)
+	for _, fl := range flags {
+		if !fl.takesValue {
+			continue
+		}
+		fmt.Fprintf(&script, "    %s)\n", shQuote(fl.name))
+		if len(fl.values) == 0 {
+			script.WriteString("        COMPREPLY=()\n        return\n        ;;\n")
+			continue
+		}
+		names := make([]string, len(fl.values))
+		for i, v := range fl.values {
+			names[i] = v.value
+		}
+		fmt.Fprintf(&script, "        values=%s\n", shQuote(strings.Join(names, "\n")))
+		if fl.multiple {
+			script.WriteString("        multiple=1\n")
+		}
+		script.WriteString("        ;;\n")
+	}
+	script.WriteString(`    *)
+        flag=""
+        ;;
+    esac
+    if [ -z "$flag" ]; then
+        case "$cur" in
+        -*)
+`)
+	var short, long []string
+	for _, fl := range flags {
+		short, long = append(short, "-"+fl.name), append(long, "--"+fl.name)
+	}
+	fmt.Fprintf(&script, `            local flags=%s
+            case "$cur" in
+            -|--*)
+                flags=%s
+                ;;
+            esac
+`, shQuote(strings.Join(short, " ")), shQuote(strings.Join(long, " ")))
+	script.WriteString(`            COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+            ;;
+        esac
+        return
+    fi
+    if [ "$multiple" = 1 ]; then
+        local i word given
+        for ((i = 1; i < last; i++)); do
+            word="${COMP_WORDS[i]}"
+            if [ "$word" = "-$flag" ] || [ "$word" = "--$flag" ]; then
+                given="${COMP_WORDS[i+1]}"
+                if [ "$given" = "=" ]; then
+                    given="${COMP_WORDS[i+2]}"
+                fi
+                values="$(printf '%s\n' "$values" | grep -vxF -- "$given")"
+            fi
+        done
+    fi
+    local IFS=$'\n'
+    COMPREPLY=($(compgen -W "$values" -- "$cur"))
+}
+
+`)
+	fmt.Fprintf(&script, "complete -o default -F %s %s\n", fn, shQuote(command))
+	return script.String()
+}
+
+func zshCompletion(command string, flags []completionFlag) string {
+	fn := "_" + identifier(command)
+	script := strings.Builder{}
+	fmt.Fprintf(&script, "#compdef %s\n\n", command)
+	fmt.Fprintf(&script, "%s() {\n", fn)
+	script.WriteString(`  local cur="${words[CURRENT]}" prev="${words[CURRENT-1]}" flag="" last=$((CURRENT - 1))
+  if [[ "$cur" == -*=* ]]; then
+    flag="${${cur%%=*}##-#}"
+    compset -P '*='
+    last=$CURRENT
+  elif [[ "$cur" == -* ]]; then
+    local -a flags
+    flags=(
+`)
+	for _, fl := range flags {
+		fmt.Fprintf(&script, "      %s\n", shQuote(describeItem("-"+fl.name, fl.usage)))
+	}
+	script.WriteString(`    )
+    _describe -t flags flag flags
+    return
+  elif [[ "$prev" == -* ]]; then
+    flag="${prev##-#}"
+  else
+    _files
+    return
+  fi
+  local -a names descriptions given candidates
+  local multiple=0
+  case "$flag" in
+`)
+	for _, fl := range flags {
+		if !fl.takesValue {
+			continue
+		}
+		fmt.Fprintf(&script, "  %s)\n", shQuote(fl.name))
+		if len(fl.values) == 0 {
+			script.WriteString("    _files\n    return\n    ;;\n")
+			continue
+		}
+		names := make([]string, len(fl.values))
+		descriptions := make([]string, len(fl.values))
+		for i, v := range fl.values {
+			names[i] = shQuote(v.value)
+			descriptions[i] = shQuote(describeItem(v.value, v.description))
+		}
+		fmt.Fprintf(&script, "    names=(%s)\n", strings.Join(names, " "))
+		fmt.Fprintf(&script, "    descriptions=(%s)\n", strings.Join(descriptions, " "))
+		if fl.multiple {
+			script.WriteString("    multiple=1\n")
+		}
+		script.WriteString("    ;;\n")
+	}
+	script.WriteString(`  *)
+    _files
+    return
+    ;;
+  esac
+  if (( multiple )); then
+    local i
+    for ((i = 2; i < last; i++)); do
+      case "${words[i]}" in
+      "-$flag"|"--$flag")
+        given+=("${words[i+1]}")
+        ;;
+      "-$flag="*|"--$flag="*)
+        given+=("${words[i]#*=}")
+        ;;
+      esac
+    done
+  fi
+  local j
+  for ((j = 1; j <= ${#names}; j++)); do
+    if (( ! ${given[(Ie)${names[j]}]} )); then
+      candidates+=("${descriptions[j]}")
+    fi
+  done
+  _describe -t values "$flag value" candidates
+}
+
+`)
+	fmt.Fprintf(&script, "compdef %s %s\n", fn, shQuote(command))
+	return script.String()
+}
+
+func fishCompletion(command string, flags []completionFlag) string {
+	fn := "__" + identifier(command) + "_flag_values"
+	script := strings.Builder{}
+	fmt.Fprintf(&script, "# fish completion for %s\n\n", command)
+	fmt.Fprintf(&script, "function %s\n", fn)
+	script.WriteString(`    set -l flag $argv[1]
+    set -l given
+    if test "$argv[2]" = multiple
+        set -l tokens (commandline -opc)
+        for i in (seq 2 (count $tokens))
+            switch $tokens[$i]
+                case -$flag --$flag
+                    set -l next (math $i + 1)
+                    if test $next -le (count $tokens)
+                        set -a given $tokens[$next]
+                    end
+                case "-$flag=*" "--$flag=*"
+                    set -a given (string split -m 1 = -- $tokens[$i])[2]
+            end
+        end
+    end
+    set -l values
+    switch $flag
+`)
+	for _, fl := range flags {
+		if len(fl.values) == 0 {
+			continue
+		}
+		values := make([]string, len(fl.values))
+		for i, v := range fl.values {
+			values[i] = fishQuote(v.value)
+			if len(v.description) > 0 {
+				values[i] += `\t` + fishQuote(v.description)
+			}
+		}
+		fmt.Fprintf(&script, "        case %s\n            set values %s\n", fishQuote(fl.name), strings.Join(values, " "))
+	}
+	script.WriteString(`    end
+    for value in $values
+        set -l name (string split -m 1 \t -- $value)[1]
+        contains -- $name $given; or printf '%s\n' $value
+    end
+end
+
+`)
+	for _, fl := range flags {
+		line := fmt.Sprintf("complete -c %s -o %s -l %s", fishQuote(command), fishQuote(fl.name), fishQuote(fl.name))
+		if len(fl.usage) > 0 {
+			line += " -d " + fishQuote(fl.usage)
+		}
+		if len(fl.values) > 0 {
+			kind := "single"
+			if fl.multiple {
+				kind = "multiple"
+			}
+			line += " -x -a " + fishQuote(fmt.Sprintf("(%s %s %s)", fn, fishQuote(fl.name), kind))
+		} else if fl.takesValue {
+			line += " -r"
+		}
+		script.WriteString(line + "\n")
+	}
+	return script.String()
+}
+
+// describeItem formats an item of the zsh _describe function.
+func describeItem(value, description string) string {
+	item := strings.ReplaceAll(value, ":", `\:`)
+	if len(description) > 0 {
+		item += ":" + description
+	}
+	return item
+}
+
+// shQuote quotes the string for bash or zsh.
+func shQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes the string for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// identifier converts the command name to a shell function name part.
+func identifier(command string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, command)
+}
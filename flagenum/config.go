@@ -0,0 +1,140 @@
+package flagenum
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Decoder unmarshals a configuration file content to the value v, like the json.Unmarshal or the yaml.Unmarshal.
+type Decoder func(data []byte, v any) error
+
+// ConfigFile sets the configuration file of the command-line flags by the CommandLine.ConfigFile.
+func ConfigFile(path string, decode Decoder) {
+	CommandLine.ConfigFile(path, decode)
+}
+
+// ConfigFile sets the configuration file that is read by the Parse, and the decoder of the file content.
+// The file content is an object which properties are values of flags, a multiple flag value is an array.
+// The values are checked like the command line ones and are overridden by environment variables and arguments.
+// A flag value is taken by the flag name key by default, see ConfigKey.
+// The Parse fails if the decode is nil.
+func (f *FlagSetExt) ConfigFile(path string, decode Decoder) {
+	f.configPath, f.configDecoder = path, decode
+}
+
+// ConfigKey sets the key of the flag value in the configuration file.
+// The dot separated key like server.port refers to a property of a nested object.
+func (f *FlagSetExt) ConfigKey(name, key string) {
+	if f.configKeys == nil {
+		f.configKeys = map[string]string{}
+	}
+	f.configKeys[name] = key
+}
+
+func (f *FlagSetExt) applyConfig() error {
+	if len(f.configPath) == 0 {
+		return nil
+	}
+	if f.configDecoder == nil {
+		return fmt.Errorf("%s: no decoder of the configuration file", f.configPath)
+	}
+	data, err := os.ReadFile(f.configPath)
+	if err != nil {
+		return err
+	}
+	var config map[string]any
+	if err := f.configDecoder(data, &config); err != nil {
+		return fmt.Errorf("%s: %w", f.configPath, err)
+	}
+	f.VisitAll(func(fl *flag.Flag) {
+		if err != nil {
+			return
+		}
+		key := fl.Name
+		if k, ok := f.configKeys[fl.Name]; ok {
+			key = k
+		}
+		value, ok := lookupConfig(config, key)
+		if !ok {
+			return
+		}
+		if err = f.setConfigValue(fl, value); err != nil {
+			err = fmt.Errorf("%s: invalid value %s of key \"%s\" for flag -%s: %w", f.configPath, configToString(value), key, fl.Name, err)
+		}
+	})
+	return err
+}
+
+func lookupConfig(config map[string]any, key string) (any, bool) {
+	if value, ok := config[key]; ok {
+		return value, true
+	}
+	parent, child, nested := strings.Cut(key, ".")
+	if !nested {
+		return nil, false
+	}
+	switch object := config[parent].(type) {
+	case map[string]any:
+		return lookupConfig(object, child)
+	case map[any]any:
+		converted := make(map[string]any, len(object))
+		for k, v := range object {
+			converted[fmt.Sprint(k)] = v
+		}
+		return lookupConfig(converted, child)
+	}
+	return nil, false
+}
+
+func (f *FlagSetExt) setConfigValue(fl *flag.Flag, value any) error {
+	var elements []string
+	if array, ok := value.([]any); ok {
+		elements = make([]string, len(array))
+		for i, element := range array {
+			s, err := configScalar(element)
+			if err != nil {
+				return err
+			}
+			elements[i] = s
+		}
+	} else {
+		s, err := configScalar(value)
+		if err != nil {
+			return err
+		}
+		elements = []string{s}
+	}
+	if v, ok := fl.Value.(enumValue); ok {
+		return v.apply(elements)
+	}
+	if len(elements) != 1 {
+		return fmt.Errorf("expected one value, got %d", len(elements))
+	}
+	return f.setPlain(fl.Name, elements[0])
+}
+
+func configScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int, int64, uint64, fmt.Stringer:
+		return fmt.Sprint(v), nil
+	case nil:
+		return "", nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
+
+func configToString(value any) string {
+	if s, ok := value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,126 @@
+package flagenum
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// BindEnv binds the command-line flag to the environment variable env by the CommandLine.BindEnv.
+func BindEnv(name, env string) {
+	CommandLine.BindEnv(name, env)
+}
+
+// AutoEnv binds the command-line enum flags to environment variables by the CommandLine.AutoEnv.
+func AutoEnv(prefix string) {
+	CommandLine.AutoEnv(prefix)
+}
+
+// BindEnv binds the flag to the environment variable env.
+// The variable value is set to the flag by the Parse before the command line arguments, so the arguments override it.
+// A value of a multiple flag is split into elements by the flag separator defined by the Separator option,
+// or by the separator defined by the EnvSeparator if the flag has no one.
+func (f *FlagSetExt) BindEnv(name, env string) {
+	if f.envs == nil {
+		f.envs = map[string]string{}
+	}
+	f.envs[name] = env
+}
+
+// AutoEnv binds every enum flag without an explicit binding to the environment variable
+// named by the prefix and the flag name converted to upper snake case, like APP_LOG_LEVEL for the prefix APP and the flag log-level.
+// An empty prefix means no prefix.
+func (f *FlagSetExt) AutoEnv(prefix string) {
+	f.autoEnv, f.envPrefix = true, prefix
+}
+
+// EnvSeparator sets the separator of multiple flag elements in environment variable values, comma by default.
+// A flag defined with the Separator option uses its own separator.
+// An element can be quoted like a value of a flag defined with the Separator option.
+func (f *FlagSetExt) EnvSeparator(sep rune) {
+	f.envSeparator = sep
+}
+
+func (f *FlagSetExt) applyEnv() (err error) {
+	sep := f.envSeparator
+	if sep == 0 {
+		sep = ','
+	}
+	if !validSeparator(sep) {
+		return fmt.Errorf("invalid environment variable separator %q", sep)
+	}
+	f.VisitAll(func(fl *flag.Flag) {
+		if err != nil {
+			return
+		}
+		env := f.envName(fl)
+		if len(env) == 0 {
+			return
+		}
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			return
+		}
+		if err = f.setElements(fl, value, sep); err != nil {
+			err = fmt.Errorf("invalid value \"%s\" for flag -%s from environment variable %s: %w", value, fl.Name, env, err)
+		}
+	})
+	return err
+}
+
+func (f *FlagSetExt) setElements(fl *flag.Flag, value string, sep rune) error {
+	v, ok := fl.Value.(enumValue)
+	if !ok {
+		return f.setPlain(fl.Name, value)
+	}
+	elements := []string{value}
+	if v.IsMultiple() {
+		if flagSep := v.separatorRune(); flagSep != 0 {
+			sep = flagSep
+		}
+		var err error
+		if elements, err = splitElements(value, sep); err != nil {
+			return err
+		}
+	}
+	return v.apply(elements)
+}
+
+func (f *FlagSetExt) envName(fl *flag.Flag) string {
+	if env, ok := f.envs[fl.Name]; ok {
+		return env
+	}
+	if _, ok := fl.Value.(enumValue); ok && f.autoEnv {
+		env := upperSnake(fl.Name)
+		if prefix := f.envPrefix; len(prefix) > 0 {
+			if !strings.HasSuffix(prefix, "_") {
+				prefix += "_"
+			}
+			env = prefix + env
+		}
+		return env
+	}
+	return ""
+}
+
+// upperSnake converts a flag name like log-level or logLevel to LOG_LEVEL.
+func upperSnake(name string) string {
+	env := strings.Builder{}
+	var prev rune
+	for _, r := range name {
+		switch {
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			env.WriteRune('_')
+			env.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			env.WriteRune(unicode.ToUpper(r))
+		default:
+			r = '_'
+			env.WriteRune(r)
+		}
+		prev = r
+	}
+	return env.String()
+}
@@ -1,52 +1,66 @@
 package flagenum
 
 import (
-	"cmp"
+	"errors"
 	"flag"
 	"fmt"
+	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 // CommandLine is the default wrapper of the flag.CommandLine flags.
 var CommandLine = Wrap(flag.CommandLine)
 
 // New creates an extended flag set.
+// The usage function of the flag set prints the flag defaults by the FlagSetExt.PrintDefaults.
 func New(name string, errorHandling flag.ErrorHandling) *FlagSetExt {
-	return Wrap(flag.NewFlagSet(name, errorHandling))
+	f := Wrap(flag.NewFlagSet(name, errorHandling))
+	f.Usage = f.defaultUsage
+	return f
 }
 
 // Wrap wraps the flagSet by a new extended flag set instance.
 func Wrap(flagSet *flag.FlagSet) *FlagSetExt {
-	return &FlagSetExt{flagSet}
+	return &FlagSetExt{FlagSet: flagSet}
 }
 
 // MultipleStrings defines a string slice flag with specified name, default values, allowed values and usage string.
 // The allowed values restrict possible values of the flag.
 // If allowed values are defined then an unexpected flag value will cause a panic.
 // The return value is the address of a slice that stores values of the flag.
-func MultipleStrings(name string, defaulValues, allowedValues []string, usage string) *[]string {
-	return CommandLine.MultipleStrings(name, defaulValues, allowedValues, usage)
+func MultipleStrings(name string, defaulValues, allowedValues []string, usage string, opts ...Option) *[]string {
+	return CommandLine.MultipleStrings(name, defaulValues, allowedValues, usage, opts...)
 }
 
 // SingleString defines a string flag with specified name, default value, allowed values and usage string.
 // The allowed values restrict possible value of the flag.
 // If allowed values are defined then an unexpected flag value will cause a panic.
 // The return value is the address of a string variable that stores the value of the flag.
-func SingleString(name string, value string, allowedValues []string, usage string) *string {
-	return CommandLine.SingleString(name, value, allowedValues, usage)
+func SingleString(name string, value string, allowedValues []string, usage string, opts ...Option) *string {
+	return CommandLine.SingleString(name, value, allowedValues, usage, opts...)
 }
 
 // FlagSetExt extends FlagSet by addition flag types.
 type FlagSetExt struct {
 	*flag.FlagSet
+	envs          map[string]string
+	envPrefix     string
+	autoEnv       bool
+	envSeparator  rune
+	configPath    string
+	configDecoder Decoder
+	configKeys    map[string]string
+	rules         []rule
+	plainSet      map[string]struct{}
 }
 
 // MultipleStrings defines a string slice flag with specified name, default values, allowed values and usage string.
 // The allowed values restrict possible values of the flag.
 // If allowed values are defined then an unexpected flag value will cause a panic.
 // The return value is the address of a slice that stores values of the flag.
-func (f *FlagSetExt) MultipleStrings(name string, defaulValues, allowedValues []string, usage string) *[]string {
-	v, err := Multiple(f.FlagSet, name, defaulValues, allowedValues, strAsIs, strAsIs, usage)
+func (f *FlagSetExt) MultipleStrings(name string, defaulValues, allowedValues []string, usage string, opts ...Option) *[]string {
+	v, err := Multiple(f.FlagSet, name, defaulValues, allowedValues, strAsIs, strAsIs, usage, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -57,8 +71,8 @@ func (f *FlagSetExt) MultipleStrings(name string, defaulValues, allowedValues []
 // The allowed values restrict possible value of the flag.
 // If allowed values are defined then an unexpected flag value will cause a panic.
 // The return value is the address of a string variable that stores the value of the flag.
-func (f *FlagSetExt) SingleString(name string, value string, allowedValues []string, usage string) *string {
-	v, err := Single(f.FlagSet, name, value, allowedValues, strAsIs, strAsIs, usage)
+func (f *FlagSetExt) SingleString(name string, value string, allowedValues []string, usage string, opts ...Option) *string {
+	v, err := Single(f.FlagSet, name, value, allowedValues, strAsIs, strAsIs, usage, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -69,95 +83,201 @@ func strAsIs(s string) string { return s }
 
 // Value a flag value.
 type Value interface {
-	cmp.Ordered
+	comparable
 }
 
 // Multiple defines a generic slice flag with specified name, default values, allowed values, string converters and usage string.
 // The allowed values restrict possible values of the flag.
 // Returns the address of a slice that stores values of the flag and an error if something wrong.
-func Multiple[V Value](flagSet *flag.FlagSet, name string, defaultValues, allowedValues []V, toVConv func(string) V, toStrConv func(V) string, usage string) (*[]V, error) {
+func Multiple[V Value](flagSet *flag.FlagSet, name string, defaultValues, allowedValues []V, toVConv func(string) V, toStrConv func(V) string, usage string, opts ...Option) (*[]V, error) {
+	return MultipleE(flagSet, name, defaultValues, allowedValues, noErr(toVConv), toStrConv, usage, opts...)
+}
+
+// MultipleE defines a generic slice flag like Multiple, but uses the failable string converter toVConv.
+// A conversion error is reported as an invalid flag value.
+// Returns the address of a slice that stores values of the flag and an error if something wrong.
+func MultipleE[V Value](flagSet *flag.FlagSet, name string, defaultValues, allowedValues []V, toVConv func(string) (V, error), toStrConv func(V) string, usage string, opts ...Option) (*[]V, error) {
 	var result []V
-	return &result, MultipleVar(flagSet, &result, name, defaultValues, allowedValues, toVConv, toStrConv, usage)
+	return &result, MultipleVarE(flagSet, &result, name, defaultValues, allowedValues, toVConv, toStrConv, usage, opts...)
 }
 
 // MultipleVar defines a generic slice flag with specified name, default values, allowed values, string converters and usage string.
 // The allowed values restrict possible values of the flag.
 // The argument p points to a slice variable in which to store values of the flag.
 // Returns an error if something wrong.
-func MultipleVar[V Value](flagSet *flag.FlagSet, p *[]V, name string, defaultValues, allowedValues []V, toVConv func(string) V, toStrConv func(V) string, usage string) error {
-	allowedUniques, err := getUniques("allowed", name, allowedValues...)
-	if err != nil {
+func MultipleVar[V Value](flagSet *flag.FlagSet, p *[]V, name string, defaultValues, allowedValues []V, toVConv func(string) V, toStrConv func(V) string, usage string, opts ...Option) error {
+	return MultipleVarE(flagSet, p, name, defaultValues, allowedValues, noErr(toVConv), toStrConv, usage, opts...)
+}
+
+// MultipleVarE defines a generic slice flag like MultipleVar, but uses the failable string converter toVConv.
+// A conversion error is reported as an invalid flag value.
+// The argument p points to a slice variable in which to store values of the flag.
+// Returns an error if something wrong.
+func MultipleVarE[V Value](flagSet *flag.FlagSet, p *[]V, name string, defaultValues, allowedValues []V, toVConv func(string) (V, error), toStrConv func(V) string, usage string, opts ...Option) error {
+	return multipleVar(flagSet, p, name, defaultValues, staticValues(allowedValues), false, toVConv, toStrConv, usage, opts...)
+}
+
+// MultipleVarFunc defines a generic slice flag like MultipleVarE, but the allowed values are returned by the provider function.
+// The provider is called once, when a flag value is set, the flag usage or completion is printed by the FlagSetExt
+// or the FlagSetExt.Parse finishes with default values to check, and its result is cached.
+// A provider error is reported as an invalid flag value or by the FlagSetExt.Parse.
+// The default values are checked when the provider is called, an invalid one is reported by the FlagSetExt.Parse.
+// The argument p points to a slice variable in which to store values of the flag.
+// Returns an error if something wrong.
+func MultipleVarFunc[V Value](flagSet *flag.FlagSet, p *[]V, name string, defaultValues []V, provider func() ([]V, error), toVConv func(string) (V, error), toStrConv func(V) string, usage string, opts ...Option) error {
+	return multipleVar(flagSet, p, name, defaultValues, provider, true, toVConv, toStrConv, usage, opts...)
+}
+
+func multipleVar[V Value](flagSet *flag.FlagSet, p *[]V, name string, defaultValues []V, provider func() ([]V, error), lazy bool, toVConv func(string) (V, error), toStrConv func(V) string, usage string, opts ...Option) error {
+	o := newOptions(opts...)
+	if o.separator != 0 {
+		if err := checkSeparator(name, o.separator); err != nil {
+			return err
+		}
+	}
+	if err := checkCount(name, o.minCount, o.maxCount); err != nil {
 		return err
 	}
-	_, err = getUniques("default", name, defaultValues...)
+	e, err := newEnum(name, usage, toVConv, toStrConv, o)
 	if err != nil {
 		return err
 	}
-	if len(allowedValues) > 0 {
-		for _, defaultValue := range defaultValues {
-			if err := checkDefault(name, defaultValue, allowedValues, allowedUniques, toStrConv); err != nil {
-				return err
-			}
+	e.defaults = defaultValues
+	values := &multipleValues[V]{
+		enum: e, values: p, uniques: map[string]struct{}{}, separator: o.separator, minCount: o.minCount, maxCount: o.maxCount,
+		incremental: o.incremental, allToken: o.allToken, noneToken: o.noneToken,
+	}
+	values.load = func() error {
+		allowed, err := provider()
+		if err != nil {
+			return fmt.Errorf("allowed values of flag -%s: %w", name, err)
+		}
+		if err := values.init(allowed, defaultValues, o); err != nil {
+			return err
+		}
+		if lazy && !values.set {
+			*p = append([]V(nil), values.defaults...)
+			flagSet.Lookup(name).DefValue = values.String()
 		}
+		return nil
 	}
-
-	*p = append(*p, defaultValues...)
-	values := multipleValues[V]{
-		name: name, values: p, allowed: allowedValues, uniques: map[V]struct{}{},
-		defaults: defaultValues, allowedUniques: allowedUniques, toVConv: toVConv, toStrConv: toStrConv,
+	if !lazy {
+		if err := values.loaded(); err != nil {
+			return err
+		}
 	}
-	flagSet.Var(&values, name, usage+getSuffix(usage, "any of", toStrConv, allowedValues...))
+	*p = append(*p, values.defaults...)
+	flagSet.Var(values, name, values.flagUsage(false))
 	return nil
 }
 
 // Single defines a generic flag with specified name, default value, allowed values, string converters and usage string.
 // The allowed values restrict possible value of the flag.
 // Returns the address of a variable that stores value of the flag and an error if something wrong.
-func Single[V Value](flagSet *flag.FlagSet, name string, value V, allowedValues []V, toVConv func(string) V, toStrConv func(V) string, usage string) (*V, error) {
+func Single[V Value](flagSet *flag.FlagSet, name string, value V, allowedValues []V, toVConv func(string) V, toStrConv func(V) string, usage string, opts ...Option) (*V, error) {
+	return SingleE(flagSet, name, value, allowedValues, noErr(toVConv), toStrConv, usage, opts...)
+}
+
+// SingleE defines a generic flag like Single, but uses the failable string converter toVConv.
+// A conversion error is reported as an invalid flag value.
+// Returns the address of a variable that stores value of the flag and an error if something wrong.
+func SingleE[V Value](flagSet *flag.FlagSet, name string, value V, allowedValues []V, toVConv func(string) (V, error), toStrConv func(V) string, usage string, opts ...Option) (*V, error) {
 	result := value
-	return &result, SingleVar(flagSet, &result, name, value, allowedValues, toVConv, toStrConv, usage)
+	return &result, SingleVarE(flagSet, &result, name, value, allowedValues, toVConv, toStrConv, usage, opts...)
 }
 
 // SingleVar defines a generic flag with specified name, default value, allowed values, string converters and usage string.
 // The allowed values restrict possible value of the flag.
 // The argument p points to a string variable in which to store the value of the flag.
 // Returns an error if something wrong.
-func SingleVar[V Value](flagSet *flag.FlagSet, p *V, name string, value V, allowedValues []V, toVConv func(string) V, toStrConv func(V) string, usage string) error {
-	allowedUniques, err := getUniques("allowed", name, allowedValues...)
+func SingleVar[V Value](flagSet *flag.FlagSet, p *V, name string, value V, allowedValues []V, toVConv func(string) V, toStrConv func(V) string, usage string, opts ...Option) error {
+	return SingleVarE(flagSet, p, name, value, allowedValues, noErr(toVConv), toStrConv, usage, opts...)
+}
+
+// SingleVarE defines a generic flag like SingleVar, but uses the failable string converter toVConv.
+// A conversion error is reported as an invalid flag value.
+// The argument p points to a variable in which to store the value of the flag.
+// Returns an error if something wrong.
+func SingleVarE[V Value](flagSet *flag.FlagSet, p *V, name string, value V, allowedValues []V, toVConv func(string) (V, error), toStrConv func(V) string, usage string, opts ...Option) error {
+	return singleVar(flagSet, p, name, value, staticValues(allowedValues), false, toVConv, toStrConv, usage, opts...)
+}
+
+// SingleVarFunc defines a generic flag like SingleVarE, but the allowed values are returned by the provider function.
+// The provider is called once, when the flag value is set, the flag usage or completion is printed by the FlagSetExt
+// or the FlagSetExt.Parse finishes with default values to check, and its result is cached.
+// A provider error is reported as an invalid flag value or by the FlagSetExt.Parse.
+// The default value is checked when the provider is called, an invalid one is reported by the FlagSetExt.Parse.
+// The argument p points to a variable in which to store the value of the flag.
+// Returns an error if something wrong.
+func SingleVarFunc[V Value](flagSet *flag.FlagSet, p *V, name string, value V, provider func() ([]V, error), toVConv func(string) (V, error), toStrConv func(V) string, usage string, opts ...Option) error {
+	return singleVar(flagSet, p, name, value, provider, true, toVConv, toStrConv, usage, opts...)
+}
+
+func singleVar[V Value](flagSet *flag.FlagSet, p *V, name string, value V, provider func() ([]V, error), lazy bool, toVConv func(string) (V, error), toStrConv func(V) string, usage string, opts ...Option) error {
+	o := newOptions(opts...)
+	if err := o.checkSingle(name); err != nil {
+		return err
+	}
+	e, err := newEnum(name, usage, toVConv, toStrConv, o)
 	if err != nil {
 		return err
 	}
 	var zero V
 	if zero != value {
-		if err := checkDefault(name, value, allowedValues, allowedUniques, toStrConv); err != nil {
+		e.defaults = []V{value}
+	}
+	values := &singleValue[V]{enum: e, value: p}
+	values.load = func() error {
+		allowed, err := provider()
+		if err != nil {
+			return fmt.Errorf("allowed values of flag -%s: %w", name, err)
+		}
+		if err := values.init(allowed, o); err != nil {
+			return err
+		}
+		if zero != value {
+			canonical, err := values.checkDefault(value)
+			if err != nil {
+				return err
+			}
+			values.defaults = []V{canonical}
+		}
+		if lazy && !values.set {
+			*p = values.defaultValue()
+			flagSet.Lookup(name).DefValue = values.String()
+		}
+		return nil
+	}
+	if !lazy {
+		if err := values.loaded(); err != nil {
 			return err
 		}
 	}
-	*p = value
-	values := singleValue[V]{name: name, value: p, allowed: allowedValues, allowedUniques: allowedUniques, toVConv: toVConv, toStrConv: toStrConv}
-	flagSet.Var(&values, name, usage+getSuffix(usage, "one of", toStrConv, allowedValues...))
+	*p = values.defaultValue()
+	flagSet.Var(values, name, values.flagUsage(false))
 	return nil
 }
 
-func getSuffix[T any](usage, countStr string, toStrConv func(T) string, allowedValues ...T) string {
-	suffix := ""
-	if len(allowedValues) > 0 {
-		suffix = "(allowed `" + countStr + " " + joinToString(toStrConv, allowedValues...) + "`)"
-	}
-	if len(usage) > 0 {
-		suffix = " " + suffix
-	}
-	return suffix
+func staticValues[V any](values []V) func() ([]V, error) {
+	return func() ([]V, error) { return values, nil }
+}
+
+func noErr[V any](toVConv func(string) V) func(string) (V, error) {
+	return func(s string) (V, error) { return toVConv(s), nil }
 }
 
-func getUniques[T Value](valueType, name string, values ...T) (map[T]struct{}, error) {
-	uniques := map[T]struct{}{}
-	for _, e := range values {
-		if err := populateUniques(valueType, e, uniques, name); err != nil {
-			return uniques, err
+func getSuffix(usage string, notes ...string) string {
+	suffix := strings.Builder{}
+	for _, note := range notes {
+		if len(note) == 0 {
+			continue
 		}
+		if len(usage) > 0 || suffix.Len() > 0 {
+			suffix.WriteString(" ")
+		}
+		suffix.WriteString("(" + note + ")")
 	}
-	return uniques, nil
+	return suffix.String()
 }
 
 func joinToString[T any](toStrConv func(T) string, values ...T) string {
@@ -171,95 +291,870 @@ func joinToString[T any](toStrConv func(T) string, values ...T) string {
 	return str.String()
 }
 
-func checkDefault[TS ~[]T, T Value](name string, defaultValue T, allowed TS, uniques map[T]struct{}, toStrConv func(T) string) error {
-	if err := checkAllowed(defaultValue, allowed, uniques, toStrConv); err != nil {
-		return fmt.Errorf("unexpected default value \"%v\" for flag -%s: %w", defaultValue, name, err)
+func toStrings[T any](toStrConv func(T) string, values ...T) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = toStrConv(v)
+	}
+	return result
+}
+
+// splitElements splits the value s into elements divided by the sep rune.
+// An element can be enclosed in double quotes to contain the separator, the inner double quote is escaped by another one.
+func splitElements(s string, sep rune) ([]string, error) {
+	var elements []string
+	for i := 0; i < len(s); {
+		if s[i] != '"' {
+			end := strings.IndexRune(s[i:], sep)
+			if end < 0 {
+				return append(elements, s[i:]), nil
+			}
+			elements = append(elements, s[i:i+end])
+			if i += end + utf8.RuneLen(sep); i == len(s) {
+				return append(elements, ""), nil
+			}
+			continue
+		}
+		element := strings.Builder{}
+		for i++; ; {
+			end := strings.IndexByte(s[i:], '"')
+			if end < 0 {
+				return nil, errors.New("missing closing quote")
+			}
+			element.WriteString(s[i : i+end])
+			if i += end + 1; i < len(s) && s[i] == '"' {
+				element.WriteByte('"')
+				i++
+				continue
+			}
+			break
+		}
+		elements = append(elements, element.String())
+		if i < len(s) {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r != sep {
+				return nil, fmt.Errorf("unexpected \"%c\" after closing quote", r)
+			}
+			if i += size; i == len(s) {
+				return append(elements, ""), nil
+			}
+		}
+	}
+	return elements, nil
+}
+
+// joinElements is the reverse of the splitElements.
+func joinElements[T any](sep rune, toStrConv func(T) string, values ...T) string {
+	str := strings.Builder{}
+	for i, v := range values {
+		if i > 0 {
+			str.WriteRune(sep)
+		}
+		element := toStrConv(v)
+		if len(element) == 0 || strings.ContainsRune(element, sep) || strings.ContainsRune(element, '"') {
+			element = "\"" + strings.ReplaceAll(element, "\"", "\"\"") + "\""
+		}
+		str.WriteString(element)
+	}
+	return str.String()
+}
+
+// enum holds the allowed values of a flag and resolves flag strings to them.
+type enum[T Value] struct {
+	name           string
+	allowed        []T
+	allowedUniques map[string]T
+	aliases        map[string][]string
+	descriptions   map[string]string
+	described      []T
+	defaults       []T
+	load           func() error
+	loadErr        error
+	usage          string
+	abbreviations  bool
+	duplicates     DuplicatePolicy
+	patterns       []pattern
+	required       bool
+	set            bool
+	failedValue    string
+	failure        error
+	completer      func(toComplete string) []string
+	normalize      func(string) string
+	toVConv        func(string) (T, error)
+	toStrConv      func(T) string
+}
+
+func newEnum[T Value](name, usage string, toVConv func(string) (T, error), toStrConv func(T) string, o *options) (enum[T], error) {
+	e := enum[T]{
+		name: name, usage: usage, abbreviations: o.abbreviations, duplicates: o.duplicates, required: o.required, completer: o.completer,
+		normalize: o.normalize(), toVConv: toVConv, toStrConv: toStrConv,
+	}
+	for _, p := range o.patterns {
+		compiled, err := p.compile(name, e.normalize)
+		if err != nil {
+			return e, err
+		}
+		e.patterns = append(e.patterns, compiled)
+	}
+	return e, nil
+}
+
+// init sets the allowed values with their aliases and descriptions.
+func (e *enum[T]) init(allowed []T, o *options) error {
+	if e.duplicates == DuplicateKeep {
+		// the allowed values are a set, so a repeated one is dropped
+		e.duplicates = DuplicateIgnore
+	}
+	allowedUniques, allowed, err := e.getUniques("allowed", allowed...)
+	e.duplicates = o.duplicates
+	if err != nil {
+		return err
+	}
+	e.allowed, e.allowedUniques = allowed, allowedUniques
+	for _, a := range o.aliases {
+		aliases, ok := a.(map[string]T)
+		if !ok {
+			return fmt.Errorf("aliases type %T doesn't match to flag -%s values", a, e.name)
+		}
+		if err := e.addAliases(aliases); err != nil {
+			return err
+		}
+	}
+	for _, d := range o.descriptions {
+		descriptions, ok := d.(map[T]string)
+		if !ok {
+			return fmt.Errorf("descriptions type %T doesn't match to flag -%s values", d, e.name)
+		}
+		if err := e.addDescriptions(descriptions); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func checkAllowed[TS ~[]T, T Value](value T, allowed TS, uniques map[T]struct{}, toStrConv func(T) string) error {
-	if len(allowed) > 0 {
-		if _, ok := uniques[value]; !ok {
-			return fmt.Errorf("must be one of %s", joinToString(toStrConv, allowed...))
+// loaded calls the allowed values provider once and returns its error, the error is cached too.
+func (e *enum[T]) loaded() error {
+	if load := e.load; load != nil {
+		e.load = nil
+		e.loadErr = load()
+	}
+	return e.loadErr
+}
+
+// loadedDefaults calls the allowed values provider if there are default values to check, an unset flag without defaults is skipped.
+func (e *enum[T]) loadedDefaults() error {
+	if len(e.defaults) == 0 && !e.set {
+		return nil
+	}
+	return e.loaded()
+}
+
+func (e *enum[T]) addDescriptions(descriptions map[T]string) error {
+	described := make([]T, 0, len(descriptions))
+	for v := range descriptions {
+		described = append(described, v)
+	}
+	sort.Slice(described, func(i, j int) bool { return e.toStrConv(described[i]) < e.toStrConv(described[j]) })
+	for _, v := range described {
+		canonical, err := e.checkAllowed(v)
+		if err != nil {
+			return fmt.Errorf("unexpected described value \"%v\" for flag -%s: %w", v, e.name, err)
+		}
+		if e.descriptions == nil {
+			e.descriptions = map[string]string{}
+		}
+		key := e.key(canonical)
+		if _, ok := e.descriptions[key]; !ok {
+			e.described = append(e.described, canonical)
 		}
+		e.descriptions[key] = descriptions[v]
 	}
 	return nil
 }
 
-func populateUniques[T Value](valueType string, value T, duplicateControl map[T]struct{}, name string) error {
-	if _, ok := duplicateControl[value]; !ok {
-		duplicateControl[value] = void
+// getUsage returns the flag usage supplemented by the allowed values note, the other notes and the required mark.
+// The allowed values printed by a description table are referred as the values below, the flag placeholder is the generic value name.
+func (e *enum[T]) getUsage(countStr string, table bool, notes ...string) string {
+	allowed := ""
+	if table {
+		allowed = "allowed " + countStr + " the values below"
+		if len(e.patterns) > 0 {
+			allowed += " or matching " + patternsToString(e.patterns)
+		}
+	} else if len(e.allowed) > 0 {
+		allowed = countStr + " " + e.allowedToString()
+		if len(e.patterns) > 0 {
+			allowed += " or matching " + patternsToString(e.patterns)
+		}
+	} else if len(e.patterns) > 0 {
+		allowed = strings.TrimSuffix(countStr, " of") + " matching " + patternsToString(e.patterns)
+	}
+	if len(allowed) > 0 && !table {
+		allowed = "allowed `" + allowed + "`"
+	}
+	notes = append([]string{allowed}, notes...)
+	if e.required {
+		notes = append(notes, "required")
+	}
+	return e.usage + getSuffix(e.usage, notes...)
+}
+
+// fail records the failure of setting the value s, it is taken by the FlagSetExt.Parse.
+func (e *enum[T]) fail(s string, err error) error {
+	e.failedValue, e.failure = s, err
+	return err
+}
+
+// takeFailure returns the last failure of setting a value and forgets it.
+func (e *enum[T]) takeFailure() (string, error) {
+	value, failure := e.failedValue, e.failure
+	e.failedValue, e.failure = "", nil
+	return value, failure
+}
+
+// missing reports whether the flag is required, but not set.
+func (e *enum[T]) missing() bool {
+	return e.required && !e.set
+}
+
+// isSet reports whether the flag is set by the command line, an environment variable or the configuration file.
+func (e *enum[T]) isSet() bool {
+	return e.set
+}
+
+// containsValue reports whether the values contain the value of the string s.
+func (e *enum[T]) containsValue(s string, values ...T) (bool, error) {
+	v, err := e.parse(s)
+	if err != nil {
+		return false, fmt.Errorf("invalid value \"%s\" for flag -%s: %w", s, e.name, err)
+	}
+	key := e.key(v)
+	for _, value := range values {
+		if e.key(value) == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// describeValues returns rows of the allowed values description table or nil if the values are not described.
+func (e *enum[T]) describeValues() []valueDescription {
+	if e.loaded() != nil || len(e.descriptions) == 0 {
+		return nil
+	}
+	return e.valueRows()
+}
+
+// valueRows returns the allowed values with their aliases and descriptions.
+func (e *enum[T]) valueRows() []valueDescription {
+	if e.loaded() != nil {
+		return nil
+	}
+	values := e.allowed
+	if len(values) == 0 {
+		values = e.described
+	}
+	defaults := make(map[string]struct{}, len(e.defaults))
+	for _, v := range e.defaults {
+		defaults[e.key(v)] = void
+	}
+	rows := make([]valueDescription, len(values))
+	for i, v := range values {
+		key := e.key(v)
+		_, isDefault := defaults[key]
+		rows[i] = valueDescription{
+			value: e.toStrConv(v), aliases: e.aliases[key], description: e.descriptions[key], isDefault: isDefault,
+		}
+	}
+	return rows
+}
+
+// candidates returns the completion candidates of a value started by the toComplete string,
+// except the values which keys are in the exclude set.
+func (e *enum[T]) candidates(toComplete string, exclude map[string]struct{}) []valueDescription {
+	var rows []valueDescription
+	if e.completer != nil {
+		for _, candidate := range e.completer(toComplete) {
+			value, description, _ := strings.Cut(candidate, "\t")
+			rows = append(rows, valueDescription{value: value, description: description})
+		}
+	} else {
+		prefix := e.normalize(toComplete)
+		for _, row := range e.valueRows() {
+			if strings.HasPrefix(e.normalize(row.value), prefix) {
+				rows = append(rows, row)
+			}
+		}
+	}
+	if len(exclude) == 0 {
+		return rows
+	}
+	candidates := rows[:0]
+	for _, row := range rows {
+		if _, ok := exclude[e.normalize(row.value)]; !ok {
+			candidates = append(candidates, row)
+		}
+	}
+	return candidates
+}
+
+// Name returns the flag name.
+func (e *enum[T]) Name() string {
+	return e.name
+}
+
+// Allowed returns the string forms of the allowed values.
+func (e *enum[T]) Allowed() []string {
+	if e.loaded() != nil {
 		return nil
 	}
+	return toStrings(e.toStrConv, e.allowed...)
+}
+
+// Defaults returns the string forms of the default values.
+func (e *enum[T]) Defaults() []string {
+	return toStrings(e.toStrConv, e.defaults...)
+}
+
+func (e *enum[T]) baseUsage() string {
+	return e.usage
+}
+
+func (e *enum[T]) addAliases(aliases map[string]T) error {
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+	for _, alias := range names {
+		target, err := e.checkAllowed(aliases[alias])
+		if err != nil {
+			return fmt.Errorf("unexpected value \"%v\" of alias \"%s\" for flag -%s: %w", aliases[alias], alias, e.name, err)
+		}
+		key := e.normalize(alias)
+		if _, ok := e.allowedUniques[key]; ok {
+			return fmt.Errorf("duplicated alias \"%s\" for flag -%s", alias, e.name)
+		}
+		e.allowedUniques[key] = target
+		if e.aliases == nil {
+			e.aliases = map[string][]string{}
+		}
+		targetKey := e.key(target)
+		e.aliases[targetKey] = append(e.aliases[targetKey], alias)
+	}
+	return nil
+}
+
+// allowedToString returns the allowed values joined by comma, an alias follows its target separated by a vertical bar.
+func (e *enum[T]) allowedToString() string {
+	str := strings.Builder{}
+	for _, v := range e.allowed {
+		if str.Len() > 0 {
+			str.WriteString(",")
+		}
+		str.WriteString(e.toStrConv(v))
+		for _, alias := range e.aliases[e.key(v)] {
+			str.WriteString("|")
+			str.WriteString(alias)
+		}
+	}
+	return str.String()
+}
+
+// key returns the string by which the value is matched to others.
+func (e *enum[T]) key(value T) string {
+	return e.normalize(e.toStrConv(value))
+}
+
+// parse converts the string to a value and checks that the value is allowed.
+// Returns the canonical allowed value.
+func (e *enum[T]) parse(s string) (T, error) {
+	if err := e.loaded(); err != nil {
+		var zero T
+		return zero, err
+	}
+	key := e.normalize(s)
+	if v, ok := e.allowedUniques[key]; ok {
+		return v, nil
+	}
+	if e.abbreviations && len(key) > 0 {
+		if candidates := e.prefixed(key); len(candidates) == 1 {
+			return candidates[0], nil
+		} else if len(candidates) > 1 {
+			names := make([]string, len(candidates))
+			for i, c := range candidates {
+				names[i] = e.toStrConv(c)
+			}
+			var zero T
+			return zero, fmt.Errorf("\"%s\" is ambiguous: %s", s, strings.Join(names, ", "))
+		}
+	}
+	v, err := e.toVConv(s)
+	if errors.Is(err, errUnknown) {
+		return v, e.notAllowed(s)
+	} else if err != nil {
+		return v, err
+	}
+	return e.checkAllowed(v)
+}
+
+// prefixed returns the allowed values that start with the prefix, or have such an alias.
+func (e *enum[T]) prefixed(prefix string) []T {
+	var candidates []T
+	for _, v := range e.allowed {
+		key := e.key(v)
+		matched := strings.HasPrefix(key, prefix)
+		for _, alias := range e.aliases[key] {
+			matched = matched || strings.HasPrefix(e.normalize(alias), prefix)
+		}
+		if matched {
+			candidates = append(candidates, v)
+		}
+	}
+	return candidates
+}
+
+func (e *enum[T]) checkDefault(defaultValue T) (T, error) {
+	v, err := e.checkAllowed(defaultValue)
+	if err != nil {
+		return v, fmt.Errorf("unexpected default value \"%v\" for flag -%s: %w", defaultValue, e.name, err)
+	}
+	return v, nil
+}
+
+func (e *enum[T]) checkAllowed(value T) (T, error) {
+	if len(e.allowed) > 0 || len(e.patterns) > 0 {
+		key := e.key(value)
+		if v, ok := e.allowedUniques[key]; ok {
+			return v, nil
+		}
+		for _, p := range e.patterns {
+			if p.match(e.toStrConv(value), key) {
+				return value, nil
+			}
+		}
+		return value, e.notAllowed(e.toStrConv(value))
+	}
+	return value, nil
+}
+
+func (e *enum[T]) notAllowed(value string) *NotAllowedError {
+	allowed := make([]string, len(e.allowed))
+	candidates := make([][]string, len(e.allowed))
+	for i, v := range e.allowed {
+		allowed[i] = e.toStrConv(v)
+		candidates[i] = append([]string{allowed[i]}, e.aliases[e.key(v)]...)
+	}
+	patterns := make([]string, len(e.patterns))
+	for i, p := range e.patterns {
+		patterns[i] = p.String()
+	}
+	return &NotAllowedError{Value: value, Allowed: allowed, Patterns: patterns, Suggestions: suggest(value, allowed, candidates)}
+}
+
+// getUniques returns the first of equal values by their keys and the values filtered by the duplicate policy.
+func (e *enum[T]) getUniques(valueType string, values ...T) (map[string]T, []T, error) {
+	uniques := map[string]T{}
+	filtered := make([]T, 0, len(values))
+	for _, v := range values {
+		key := e.key(v)
+		if _, ok := uniques[key]; !ok {
+			uniques[key] = v
+		} else if keep, err := e.repeated(valueType, v); err != nil {
+			return uniques, nil, err
+		} else if !keep {
+			continue
+		}
+		filtered = append(filtered, v)
+	}
+	return uniques, filtered, nil
+}
+
+// populateUniques registers the value in the duplicateControl and reports whether the value must be stored.
+func (e *enum[T]) populateUniques(valueType string, value T, duplicateControl map[string]struct{}) (bool, error) {
+	key := e.key(value)
+	if _, ok := duplicateControl[key]; !ok {
+		duplicateControl[key] = void
+		return true, nil
+	}
+	return e.repeated(valueType, value)
+}
+
+// repeated handles the repeated value by the duplicate policy and reports whether the value must be stored.
+func (e *enum[T]) repeated(valueType string, value T) (bool, error) {
+	switch e.duplicates {
+	case DuplicateIgnore:
+		return false, nil
+	case DuplicateKeep:
+		return true, nil
+	default:
+		return false, e.duplicated(valueType, value)
+	}
+}
+
+func (e *enum[T]) duplicated(valueType string, value T) error {
 	if len(valueType) > 0 {
 		valueType += " "
 	}
-	return fmt.Errorf("duplicated %svalue \"%v\" for flag -%s", valueType, value, name)
+	return fmt.Errorf("duplicated %svalue \"%v\" for flag -%s", valueType, value, e.name)
 }
 
 var void struct{}
 
 type multipleValues[T Value] struct {
-	name           string
+	enum[T]
 	values         *[]T
-	defaults       []T
-	allowed        []T
-	uniques        map[T]struct{}
-	allowedUniques map[T]struct{}
+	uniques        map[string]struct{}
+	tokenSelected  map[string]struct{}
 	defaultCleared bool
-	toVConv        func(string) T
-	toStrConv      func(T) string
+	baseCount      int
+	separator      rune
+	minCount       int
+	maxCount       int
+	incremental    bool
+	allToken       string
+	noneToken      string
+}
+
+var _ enumValue = (*multipleValues[string])(nil)
+
+func (f *multipleValues[T]) flagUsage(table bool) string {
+	countStr, notes := "any of", []string(nil)
+	if bounds := countBounds(f.minCount, f.maxCount); len(bounds) > 0 {
+		if table || len(f.allowed) > 0 {
+			countStr = bounds + " of"
+		} else {
+			notes = append(notes, bounds+" values")
+		}
+	}
+	if tokens := f.tokensNote(); len(tokens) > 0 {
+		notes = append(notes, tokens)
+	}
+	if f.incremental {
+		notes = append(notes, "+value adds to defaults, -value removes")
+	}
+	return f.getUsage(countStr, table, notes...)
+}
+
+// init sets the allowed values and checks the default values.
+func (f *multipleValues[T]) init(allowed, defaultValues []T, o *options) error {
+	if err := f.enum.init(allowed, o); err != nil {
+		return err
+	}
+	if f.incremental {
+		for _, v := range f.allowed {
+			if s := f.toStrConv(v); strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+				return fmt.Errorf("allowed value \"%s\" of flag -%s conflicts with the incremental syntax", s, f.name)
+			}
+		}
+	}
+	if err := checkTokens(&f.enum, f.allToken, f.noneToken); err != nil {
+		return err
+	}
+	_, defaultValues, err := f.getUniques("default", defaultValues...)
+	if err != nil {
+		return err
+	}
+	f.defaults = make([]T, len(defaultValues))
+	for i, defaultValue := range defaultValues {
+		if f.defaults[i], err = f.checkDefault(defaultValue); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-var _ flag.Value = (*multipleValues[string])(nil)
+// validate checks the number of the flag values.
+func (f *multipleValues[T]) validate() error {
+	count := len(f.Values())
+	if count < f.minCount {
+		return fmt.Errorf("flag -%s requires at least %s", f.name, plural(f.minCount, "value"))
+	}
+	if f.maxCount > 0 && count > f.maxCount {
+		return fmt.Errorf("flag -%s accepts at most %s", f.name, plural(f.maxCount, "value"))
+	}
+	return nil
+}
+
+func (f *multipleValues[T]) separatorRune() rune {
+	return f.separator
+}
+
+// IsMultiple returns true.
+func (f *multipleValues[T]) IsMultiple() bool {
+	return true
+}
 
 func (f *multipleValues[T]) String() string {
 	v := f.Values()
 	c := f.toStrConv
 	if v != nil && c != nil {
+		if f.separator != 0 {
+			return joinElements(f.separator, c, v...)
+		}
 		return joinToString(f.toStrConv, v...)
 	}
 	return ""
 }
 
 func (f *multipleValues[T]) Set(s string) error {
+	if err := f.loaded(); err != nil {
+		return f.fail(s, err)
+	}
+	elements := []string{s}
+	if f.separator != 0 {
+		var err error
+		if elements, err = splitElements(s, f.separator); err != nil {
+			return f.fail(s, err)
+		}
+	}
+	for _, element := range elements {
+		if err := f.setElement(element); err != nil {
+			if len(elements) > 1 {
+				err = fmt.Errorf("element \"%s\": %w", element, err)
+			}
+			return f.fail(s, err)
+		}
+	}
+	f.clearDefault()
+	f.set = true
+	return nil
+}
+
+// setElement adds the value of the element, or removes it if the element has the incremental syntax minus prefix.
+// A value without the incremental syntax prefix replaces the defaults, the ones kept by the incremental syntax too.
+func (f *multipleValues[T]) setElement(element string) error {
+	if values, ok := f.expandToken(element); ok {
+		*f.values, f.uniques, f.tokenSelected = values, f.uniquesOf(values), f.uniquesOf(values)
+		f.defaultCleared, f.baseCount = true, 0
+		return nil
+	}
+	if f.incremental && len(element) > 0 {
+		switch element[0] {
+		case '+':
+			f.keepDefault()
+			return f.add(element[1:])
+		case '-':
+			f.keepDefault()
+			return f.remove(element[1:])
+		}
+	}
+	f.replaceDefault()
+	return f.add(element)
+}
+
+// expandToken returns the values selected by the element if it is a reserved token.
+func (f *multipleValues[T]) expandToken(element string) ([]T, bool) {
+	key := f.normalize(element)
+	switch {
+	case len(f.allToken) > 0 && key == f.normalize(f.allToken):
+		return append([]T{}, f.allowed...), true
+	case len(f.noneToken) > 0 && key == f.normalize(f.noneToken):
+		return []T{}, true
+	}
+	return nil, false
+}
+
+func (f *multipleValues[T]) uniquesOf(values []T) map[string]struct{} {
+	uniques := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		uniques[f.key(v)] = void
+	}
+	return uniques
+}
+
+// completeValues returns the completion candidates of the last element of the toComplete value,
+// the values already given to the flag and the preceding elements are excluded.
+// The prefix is the part of the toComplete value before the last element.
+func (f *multipleValues[T]) completeValues(toComplete string, given []string) (prefix string, candidates []valueDescription) {
+	if f.separator != 0 {
+		if i := strings.LastIndex(toComplete, string(f.separator)); i >= 0 {
+			prefix = toComplete[:i+utf8.RuneLen(f.separator)]
+			given = append(append([]string{}, given...), toComplete[:i])
+			toComplete = toComplete[len(prefix):]
+		}
+	}
+	exclude := map[string]struct{}{}
+	for _, arg := range given {
+		elements := []string{arg}
+		if f.separator != 0 {
+			var err error
+			if elements, err = splitElements(arg, f.separator); err != nil {
+				continue
+			}
+		}
+		for _, element := range elements {
+			if f.incremental {
+				element = strings.TrimPrefix(element, "+")
+			}
+			if v, err := f.parse(element); err == nil {
+				exclude[f.key(v)] = void
+			}
+		}
+	}
+	return prefix, f.candidates(toComplete, exclude)
+}
+
+// tokensNote returns the usage note of the reserved tokens.
+func (f *multipleValues[T]) tokensNote() string {
+	var tokens []string
+	if len(f.allToken) > 0 {
+		tokens = append(tokens, f.allToken+" selects all values")
+	}
+	if len(f.noneToken) > 0 {
+		tokens = append(tokens, f.noneToken+" selects none")
+	}
+	return strings.Join(tokens, ", ")
+}
+
+func (f *multipleValues[T]) clearDefault() {
 	if !f.defaultCleared {
 		*f.values = nil
 		f.defaultCleared = true
 	}
-	v := f.toVConv(s)
-	if err := populateUniques("", v, f.uniques, f.name); err != nil {
+}
+
+// keepDefault makes the default values the base of the incremental changes.
+func (f *multipleValues[T]) keepDefault() {
+	if !f.defaultCleared {
+		f.uniques = f.uniquesOf(*f.values)
+		f.defaultCleared, f.baseCount = true, len(*f.values)
+	}
+}
+
+// replaceDefault drops the default values, the ones remaining in the base of the incremental changes too.
+// The values added by the incremental syntax are kept.
+func (f *multipleValues[T]) replaceDefault() {
+	if !f.defaultCleared {
+		f.clearDefault()
+	} else if f.baseCount > 0 {
+		*f.values = append([]T(nil), (*f.values)[f.baseCount:]...)
+		f.uniques, f.baseCount = f.uniquesOf(*f.values), 0
+	}
+}
+
+func (f *multipleValues[T]) remove(s string) error {
+	v, err := f.parse(s)
+	if err != nil {
+		return err
+	}
+	key := f.key(v)
+	delete(f.tokenSelected, key)
+	values, baseCount := (*f.values)[:0], f.baseCount
+	for i, value := range *f.values {
+		if f.key(value) != key {
+			values = append(values, value)
+		} else if i < baseCount {
+			f.baseCount--
+		}
+	}
+	*f.values = values
+	delete(f.uniques, key)
+	return nil
+}
+
+// add adds the value of the string s, a value already selected by a reserved token is ignored.
+func (f *multipleValues[T]) add(s string) error {
+	v, err := f.parse(s)
+	if err != nil {
 		return err
 	}
-	if err := checkAllowed(v, f.allowed, f.allowedUniques, f.toStrConv); err != nil {
+	if _, ok := f.tokenSelected[f.key(v)]; ok {
+		return nil
+	}
+	if keep, err := f.populateUniques("", v, f.uniques); err != nil || !keep {
 		return err
 	}
 	*f.values = append(*f.values, v)
 	return nil
 }
 
+// apply replaces the values by the elements taken from a source like an environment variable.
+// The command line values replace the applied ones.
+func (f *multipleValues[T]) apply(elements []string) error {
+	if err := f.loaded(); err != nil {
+		return err
+	}
+	values := make([]T, 0, len(elements))
+	uniques, tokenSelected := map[string]struct{}{}, map[string]struct{}{}
+	for _, element := range elements {
+		if expanded, ok := f.expandToken(element); ok {
+			values, uniques, tokenSelected = expanded, f.uniquesOf(expanded), f.uniquesOf(expanded)
+			continue
+		}
+		v, err := f.parse(element)
+		keep := false
+		if _, selected := tokenSelected[f.key(v)]; err == nil && !selected {
+			keep, err = f.populateUniques("", v, uniques)
+		}
+		if err != nil {
+			if len(elements) > 1 {
+				return fmt.Errorf("element \"%s\": %w", element, err)
+			}
+			return err
+		}
+		if keep {
+			values = append(values, v)
+		}
+	}
+	*f.values, f.tokenSelected = values, nil
+	f.defaultCleared, f.baseCount = false, 0
+	f.set = true
+	return nil
+}
+
+func (f *multipleValues[T]) reset() {
+	*f.values = append([]T(nil), f.defaults...)
+	f.uniques, f.tokenSelected = map[string]struct{}{}, nil
+	f.defaultCleared, f.baseCount, f.set = false, 0, false
+}
+
+func (f *multipleValues[T]) contains(s string) (bool, error) {
+	return f.containsValue(s, f.Values()...)
+}
+
 func (f *multipleValues[T]) Get() any {
 	return f.Values()
 }
 
 func (f *multipleValues[T]) Values() []T {
-	if v := f.values; v != nil && len(*v) > 0 {
+	if v := f.values; v != nil {
 		return *v
 	}
 	return f.defaults
 }
 
 type singleValue[T Value] struct {
-	name           string
-	value          *T
-	allowed        []T
-	allowedUniques map[T]struct{}
-	toVConv        func(string) T
-	toStrConv      func(T) string
+	enum[T]
+	value *T
+}
+
+var _ enumValue = (*singleValue[string])(nil)
+
+func (f *singleValue[T]) flagUsage(table bool) string {
+	return f.getUsage("one of", table)
+}
+
+// completeValues returns the completion candidates of the toComplete value.
+func (f *singleValue[T]) completeValues(toComplete string, _ []string) (string, []valueDescription) {
+	return "", f.candidates(toComplete, nil)
 }
 
-var _ flag.Value = (*singleValue[string])(nil)
+func (f *singleValue[T]) separatorRune() rune {
+	return 0
+}
+
+// IsMultiple returns false.
+func (f *singleValue[T]) IsMultiple() bool {
+	return false
+}
+
+func (f *singleValue[T]) validate() error {
+	return nil
+}
 
 func (f *singleValue[T]) String() string {
 	v := f.Value()
@@ -271,14 +1166,39 @@ func (f *singleValue[T]) String() string {
 }
 
 func (f *singleValue[T]) Set(s string) error {
-	v := f.toVConv(s)
-	if err := checkAllowed(v, f.allowed, f.allowedUniques, f.toStrConv); err != nil {
-		return err
+	v, err := f.parse(s)
+	if err != nil {
+		return f.fail(s, err)
 	}
 	*f.value = v
+	f.set = true
 	return nil
 }
 
+func (f *singleValue[T]) apply(elements []string) error {
+	if len(elements) != 1 {
+		return fmt.Errorf("expected one value, got %d", len(elements))
+	}
+	return f.Set(elements[0])
+}
+
+func (f *singleValue[T]) reset() {
+	*f.value = f.defaultValue()
+	f.set = false
+}
+
+func (f *singleValue[T]) defaultValue() T {
+	var value T
+	if len(f.defaults) > 0 {
+		value = f.defaults[0]
+	}
+	return value
+}
+
+func (f *singleValue[T]) contains(s string) (bool, error) {
+	return f.containsValue(s, *f.value)
+}
+
 func (f *singleValue[T]) Get() any {
 	return f.Value()
 }
@@ -0,0 +1,32 @@
+package flagenum
+
+import "flag"
+
+// EnumValue is implemented by the values of flags defined by the package.
+// The Get method returns the typed flag value: a pointer to the value of a single flag or a slice of multiple flag values.
+type EnumValue interface {
+	flag.Getter
+	// Name returns the flag name.
+	Name() string
+	// Allowed returns the string forms of the allowed values in the definition order, or nil if any value is allowed.
+	Allowed() []string
+	// Defaults returns the string forms of the default values.
+	Defaults() []string
+	// IsMultiple reports whether the flag accepts multiple values.
+	IsMultiple() bool
+}
+
+// EnumFlags visits the command-line enum flags by the CommandLine.EnumFlags.
+func EnumFlags(fn func(*flag.Flag, EnumValue)) {
+	CommandLine.EnumFlags(fn)
+}
+
+// EnumFlags visits the enum flags in lexicographical order, calling fn for each flag and its value.
+// It visits all enum flags, even those not set.
+func (f *FlagSetExt) EnumFlags(fn func(*flag.Flag, EnumValue)) {
+	f.VisitAll(func(fl *flag.Flag) {
+		if v, ok := fl.Value.(EnumValue); ok {
+			fn(fl, v)
+		}
+	})
+}
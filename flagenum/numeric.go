@@ -0,0 +1,278 @@
+package flagenum
+
+import (
+	"strconv"
+	"time"
+)
+
+// MultipleInts defines an int slice flag with specified name, default values, allowed values and usage string
+// by the CommandLine.MultipleInts.
+func MultipleInts(name string, defaultValues, allowedValues []int, usage string, opts ...Option) *[]int {
+	return CommandLine.MultipleInts(name, defaultValues, allowedValues, usage, opts...)
+}
+
+// SingleInt defines an int flag with specified name, default value, allowed values and usage string
+// by the CommandLine.SingleInt.
+func SingleInt(name string, value int, allowedValues []int, usage string, opts ...Option) *int {
+	return CommandLine.SingleInt(name, value, allowedValues, usage, opts...)
+}
+
+// MultipleInt64s defines an int64 slice flag with specified name, default values, allowed values and usage string
+// by the CommandLine.MultipleInt64s.
+func MultipleInt64s(name string, defaultValues, allowedValues []int64, usage string, opts ...Option) *[]int64 {
+	return CommandLine.MultipleInt64s(name, defaultValues, allowedValues, usage, opts...)
+}
+
+// SingleInt64 defines an int64 flag with specified name, default value, allowed values and usage string
+// by the CommandLine.SingleInt64.
+func SingleInt64(name string, value int64, allowedValues []int64, usage string, opts ...Option) *int64 {
+	return CommandLine.SingleInt64(name, value, allowedValues, usage, opts...)
+}
+
+// MultipleUints defines a uint slice flag with specified name, default values, allowed values and usage string
+// by the CommandLine.MultipleUints.
+func MultipleUints(name string, defaultValues, allowedValues []uint, usage string, opts ...Option) *[]uint {
+	return CommandLine.MultipleUints(name, defaultValues, allowedValues, usage, opts...)
+}
+
+// SingleUint defines a uint flag with specified name, default value, allowed values and usage string
+// by the CommandLine.SingleUint.
+func SingleUint(name string, value uint, allowedValues []uint, usage string, opts ...Option) *uint {
+	return CommandLine.SingleUint(name, value, allowedValues, usage, opts...)
+}
+
+// MultipleUint64s defines a uint64 slice flag with specified name, default values, allowed values and usage string
+// by the CommandLine.MultipleUint64s.
+func MultipleUint64s(name string, defaultValues, allowedValues []uint64, usage string, opts ...Option) *[]uint64 {
+	return CommandLine.MultipleUint64s(name, defaultValues, allowedValues, usage, opts...)
+}
+
+// SingleUint64 defines a uint64 flag with specified name, default value, allowed values and usage string
+// by the CommandLine.SingleUint64.
+func SingleUint64(name string, value uint64, allowedValues []uint64, usage string, opts ...Option) *uint64 {
+	return CommandLine.SingleUint64(name, value, allowedValues, usage, opts...)
+}
+
+// MultipleFloat64s defines a float64 slice flag with specified name, default values, allowed values and usage string
+// by the CommandLine.MultipleFloat64s.
+func MultipleFloat64s(name string, defaultValues, allowedValues []float64, usage string, opts ...Option) *[]float64 {
+	return CommandLine.MultipleFloat64s(name, defaultValues, allowedValues, usage, opts...)
+}
+
+// SingleFloat64 defines a float64 flag with specified name, default value, allowed values and usage string
+// by the CommandLine.SingleFloat64.
+func SingleFloat64(name string, value float64, allowedValues []float64, usage string, opts ...Option) *float64 {
+	return CommandLine.SingleFloat64(name, value, allowedValues, usage, opts...)
+}
+
+// MultipleDurations defines a duration slice flag with specified name, default values, allowed values and usage string
+// by the CommandLine.MultipleDurations.
+func MultipleDurations(name string, defaultValues, allowedValues []time.Duration, usage string, opts ...Option) *[]time.Duration {
+	return CommandLine.MultipleDurations(name, defaultValues, allowedValues, usage, opts...)
+}
+
+// SingleDuration defines a duration flag with specified name, default value, allowed values and usage string
+// by the CommandLine.SingleDuration.
+func SingleDuration(name string, value time.Duration, allowedValues []time.Duration, usage string, opts ...Option) *time.Duration {
+	return CommandLine.SingleDuration(name, value, allowedValues, usage, opts...)
+}
+
+// MultipleInts defines an int slice flag with specified name, default values, allowed values and usage string.
+// The allowed values restrict possible values of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a slice that stores values of the flag.
+func (f *FlagSetExt) MultipleInts(name string, defaultValues, allowedValues []int, usage string, opts ...Option) *[]int {
+	v, err := MultipleE(f.FlagSet, name, defaultValues, allowedValues, parseInt, strconv.Itoa, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SingleInt defines an int flag with specified name, default value, allowed values and usage string.
+// The allowed values restrict possible value of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of an int variable that stores the value of the flag.
+func (f *FlagSetExt) SingleInt(name string, value int, allowedValues []int, usage string, opts ...Option) *int {
+	v, err := SingleE(f.FlagSet, name, value, allowedValues, parseInt, strconv.Itoa, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MultipleInt64s defines an int64 slice flag with specified name, default values, allowed values and usage string.
+// The allowed values restrict possible values of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a slice that stores values of the flag.
+func (f *FlagSetExt) MultipleInt64s(name string, defaultValues, allowedValues []int64, usage string, opts ...Option) *[]int64 {
+	v, err := MultipleE(f.FlagSet, name, defaultValues, allowedValues, parseInt64, formatInt64, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SingleInt64 defines an int64 flag with specified name, default value, allowed values and usage string.
+// The allowed values restrict possible value of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of an int64 variable that stores the value of the flag.
+func (f *FlagSetExt) SingleInt64(name string, value int64, allowedValues []int64, usage string, opts ...Option) *int64 {
+	v, err := SingleE(f.FlagSet, name, value, allowedValues, parseInt64, formatInt64, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MultipleUints defines a uint slice flag with specified name, default values, allowed values and usage string.
+// The allowed values restrict possible values of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a slice that stores values of the flag.
+func (f *FlagSetExt) MultipleUints(name string, defaultValues, allowedValues []uint, usage string, opts ...Option) *[]uint {
+	v, err := MultipleE(f.FlagSet, name, defaultValues, allowedValues, parseUint, formatUint, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SingleUint defines a uint flag with specified name, default value, allowed values and usage string.
+// The allowed values restrict possible value of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a uint variable that stores the value of the flag.
+func (f *FlagSetExt) SingleUint(name string, value uint, allowedValues []uint, usage string, opts ...Option) *uint {
+	v, err := SingleE(f.FlagSet, name, value, allowedValues, parseUint, formatUint, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MultipleUint64s defines a uint64 slice flag with specified name, default values, allowed values and usage string.
+// The allowed values restrict possible values of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a slice that stores values of the flag.
+func (f *FlagSetExt) MultipleUint64s(name string, defaultValues, allowedValues []uint64, usage string, opts ...Option) *[]uint64 {
+	v, err := MultipleE(f.FlagSet, name, defaultValues, allowedValues, parseUint64, formatUint64, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SingleUint64 defines a uint64 flag with specified name, default value, allowed values and usage string.
+// The allowed values restrict possible value of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a uint64 variable that stores the value of the flag.
+func (f *FlagSetExt) SingleUint64(name string, value uint64, allowedValues []uint64, usage string, opts ...Option) *uint64 {
+	v, err := SingleE(f.FlagSet, name, value, allowedValues, parseUint64, formatUint64, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MultipleFloat64s defines a float64 slice flag with specified name, default values, allowed values and usage string.
+// The allowed values restrict possible values of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a slice that stores values of the flag.
+func (f *FlagSetExt) MultipleFloat64s(name string, defaultValues, allowedValues []float64, usage string, opts ...Option) *[]float64 {
+	v, err := MultipleE(f.FlagSet, name, defaultValues, allowedValues, parseFloat64, formatFloat64, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SingleFloat64 defines a float64 flag with specified name, default value, allowed values and usage string.
+// The allowed values restrict possible value of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a float64 variable that stores the value of the flag.
+func (f *FlagSetExt) SingleFloat64(name string, value float64, allowedValues []float64, usage string, opts ...Option) *float64 {
+	v, err := SingleE(f.FlagSet, name, value, allowedValues, parseFloat64, formatFloat64, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MultipleDurations defines a duration slice flag with specified name, default values, allowed values and usage string.
+// The allowed values restrict possible values of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a slice that stores values of the flag.
+func (f *FlagSetExt) MultipleDurations(name string, defaultValues, allowedValues []time.Duration, usage string, opts ...Option) *[]time.Duration {
+	v, err := MultipleE(f.FlagSet, name, defaultValues, allowedValues, time.ParseDuration, time.Duration.String, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SingleDuration defines a duration flag with specified name, default value, allowed values and usage string.
+// The allowed values restrict possible value of the flag.
+// If allowed values are defined then an unexpected flag value will cause a panic.
+// The return value is the address of a duration variable that stores the value of the flag.
+func (f *FlagSetExt) SingleDuration(name string, value time.Duration, allowedValues []time.Duration, usage string, opts ...Option) *time.Duration {
+	v, err := SingleE(f.FlagSet, name, value, allowedValues, time.ParseDuration, time.Duration.String, usage, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func parseInt(s string) (int, error) {
+	v, err := strconv.ParseInt(s, 0, strconv.IntSize)
+	if err != nil {
+		return 0, err
+	}
+	return int(v), nil
+}
+
+func parseInt64(s string) (int64, error) {
+	v, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+func formatInt64(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func parseUint(s string) (uint, error) {
+	v, err := strconv.ParseUint(s, 0, strconv.IntSize)
+	if err != nil {
+		return 0, err
+	}
+	return uint(v), nil
+}
+
+func formatUint(v uint) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func parseUint64(s string) (uint64, error) {
+	v, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+func formatUint64(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+func parseFloat64(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+func formatFloat64(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,274 @@
+package flagenum
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Option customizes a flag defined by the package functions.
+// An option of multiple flags only, like the Separator, fails the definition of a single flag.
+type Option func(*options)
+
+type options struct {
+	separator     rune
+	normalizers   []Normalizer
+	aliases       []any
+	descriptions  []any
+	abbreviations bool
+	duplicates    DuplicatePolicy
+	patterns      []pattern
+	minCount      int
+	maxCount      int
+	required      bool
+	incremental   bool
+	allToken      string
+	noneToken     string
+	completer     func(toComplete string) []string
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// Separator enables a list syntax for values of a multiple flag, like --api rest,grpc.
+// The elements of a value are divided by the sep rune and checked separately.
+// An element containing the separator or a double quote must be enclosed in double quotes,
+// a double quote inside of the quoted element is escaped by another one, as in CSV.
+// An empty value clears the flag.
+func Separator(sep rune) Option {
+	return func(o *options) { o.separator = sep }
+}
+
+func checkSeparator(name string, sep rune) error {
+	if !validSeparator(sep) {
+		return fmt.Errorf("invalid separator %q for flag -%s", sep, name)
+	}
+	return nil
+}
+
+func validSeparator(sep rune) bool {
+	return sep != '"' && sep != utf8.RuneError && utf8.ValidRune(sep)
+}
+
+// Normalizer converts a flag value string to a form in which it is matched to the allowed values.
+type Normalizer func(string) string
+
+var (
+	// IgnoreCase matches values regardless of letter case, using Unicode simple case folding.
+	IgnoreCase Normalizer = foldCase
+	// TrimSpace matches values regardless of leading and trailing white space.
+	TrimSpace Normalizer = strings.TrimSpace
+)
+
+// Matching sets a policy of matching flag values to the allowed values.
+// The normalizers are applied in the given order to both a flag value and the string form of every allowed value,
+// a flag value matches an allowed value if the results are equal. The flag stores the matched allowed value.
+// The same policy is used to detect duplicated allowed, default and multiple flag values.
+// The Unicode normalization forms are provided by the unorm subpackage, like unorm.NFC.
+func Matching(normalizers ...Normalizer) Option {
+	return func(o *options) { o.normalizers = append(o.normalizers, normalizers...) }
+}
+
+// Aliases defines extra accepted spellings of the allowed values.
+// Every alias maps to an allowed value that is stored by the flag when the alias is set.
+// An alias and its target are treated as the same value by the duplicate checks.
+// The aliases are matched by the same policy as the allowed values and are shown in usage next to their targets.
+// The type of the map values must match the type of the flag values.
+func Aliases[V Value](aliases map[string]V) Option {
+	return func(o *options) { o.aliases = append(o.aliases, aliases) }
+}
+
+// Descriptions attaches descriptions to the allowed values of a flag.
+// The described values are printed by the FlagSetExt.PrintDefaults as an indented table under the flag instead of the compact allowed list.
+// The type of the map keys must match the type of the flag values.
+func Descriptions[V Value](descriptions map[V]string) Option {
+	return func(o *options) { o.descriptions = append(o.descriptions, descriptions) }
+}
+
+// Abbreviations enables matching of a flag value by any unambiguous prefix of an allowed value or its alias.
+// The flag stores the full allowed value. An ambiguous prefix is reported with the candidate values.
+func Abbreviations() Option {
+	return func(o *options) { o.abbreviations = true }
+}
+
+// DuplicatePolicy defines how a flag handles a repeated value.
+type DuplicatePolicy int
+
+const (
+	// DuplicateError reports a repeated value as an error. It is the default policy.
+	DuplicateError DuplicatePolicy = iota
+	// DuplicateIgnore silently drops a repeated value, the first occurrence is kept.
+	DuplicateIgnore
+	// DuplicateKeep stores every occurrence of a value, as a list does.
+	DuplicateKeep
+)
+
+// Duplicates sets the policy of handling repeated values of a multiple flag, including the default values,
+// and repeated allowed values of any flag. Values are compared by the matching policy, see Matching.
+// The allowed values are a set, so a repeated allowed value is dropped by the DuplicateKeep too.
+// The DuplicateKeep lets a multiple flag model an ordered repeatable list like a chain of middleware names.
+func Duplicates(policy DuplicatePolicy) Option {
+	return func(o *options) { o.duplicates = policy }
+}
+
+// MinCount sets the minimal number of values of a multiple flag.
+// The number is checked by the FlagSetExt.Parse when parsing finishes, the default values are counted if the flag is not set.
+func MinCount(n int) Option {
+	return func(o *options) { o.minCount = n }
+}
+
+// MaxCount sets the maximal number of values of a multiple flag, zero means no limit.
+// The number is checked by the FlagSetExt.Parse when parsing finishes, the default values are counted if the flag is not set.
+func MaxCount(n int) Option {
+	return func(o *options) { o.maxCount = n }
+}
+
+func checkCount(name string, minCount, maxCount int) error {
+	if minCount < 0 || maxCount < 0 || maxCount > 0 && minCount > maxCount {
+		return fmt.Errorf("invalid value count bounds %d..%d for flag -%s", minCount, maxCount, name)
+	}
+	return nil
+}
+
+// countBounds returns the value count bounds note like "1 to 3", or an empty string if there are no bounds.
+func countBounds(minCount, maxCount int) string {
+	switch {
+	case maxCount == 0 && minCount == 0:
+		return ""
+	case maxCount == 0:
+		return fmt.Sprintf("at least %d", minCount)
+	case minCount == maxCount:
+		return strconv.Itoa(minCount)
+	case minCount == 0:
+		return fmt.Sprintf("at most %d", maxCount)
+	default:
+		return fmt.Sprintf("%d to %d", minCount, maxCount)
+	}
+}
+
+func plural(n int, noun string) string {
+	if n != 1 {
+		noun += "s"
+	}
+	return strconv.Itoa(n) + " " + noun
+}
+
+// Incremental enables the syntax of changing the default values of a multiple flag:
+// +value adds the value to the defaults, -value removes it, a value without the prefix replaces the defaults as usual.
+// The prefixed values change the current values, which are the defaults until a value without the prefix is given.
+// A value without the prefix given after the prefixed ones drops the remaining defaults, but keeps the added values,
+// so with the defaults rest,grpc the --api -grpc --api ws gives ws and the --api +soap --api ws gives soap,ws.
+// The added and removed values are checked like others. An allowed value cannot start with the plus or minus sign.
+func Incremental() Option {
+	return func(o *options) { o.incremental = true }
+}
+
+// AllToken reserves the token that selects all allowed values of a multiple flag, like --api all.
+// The token replaces the values given before it, the following values are added to the selection
+// and can be removed from it by the Incremental syntax. A following value already selected by the token is ignored
+// regardless of the duplicate policy, like --api all,rest. The token is matched by the flag matching policy.
+func AllToken(token string) Option {
+	return func(o *options) { o.allToken = token }
+}
+
+// NoneToken reserves the token that selects no values of a multiple flag, like --api none.
+// The token replaces the values given before it, the following values are added to the empty selection.
+// The token is matched by the flag matching policy.
+func NoneToken(token string) Option {
+	return func(o *options) { o.noneToken = token }
+}
+
+// checkTokens checks that the reserved tokens don't collide with the allowed values, their aliases and each other.
+func checkTokens[T Value](e *enum[T], allToken, noneToken string) error {
+	if len(allToken) > 0 && len(e.allowed) == 0 {
+		return fmt.Errorf("token \"%s\" of flag -%s requires allowed values", allToken, e.name)
+	}
+	if len(allToken) > 0 && e.normalize(allToken) == e.normalize(noneToken) {
+		return fmt.Errorf("duplicated token \"%s\" for flag -%s", allToken, e.name)
+	}
+	for _, token := range []string{allToken, noneToken} {
+		if len(token) == 0 {
+			continue
+		}
+		if v, ok := e.allowedUniques[e.normalize(token)]; ok {
+			return fmt.Errorf("allowed value \"%s\" of flag -%s collides with reserved token \"%s\"", e.toStrConv(v), e.name, token)
+		}
+	}
+	return nil
+}
+
+// Required marks a flag as required. The FlagSetExt.Parse reports an error if the flag is not set
+// by the command line, an environment variable or the configuration file, the default value doesn't satisfy it.
+// The flag usage is marked by the (required) note.
+func Required() Option {
+	return func(o *options) { o.required = true }
+}
+
+// Completer sets the function that returns dynamic completion candidates of a flag value for the runtime completion,
+// see FlagSetExt.Complete. The allowed values are the candidates by default.
+// A candidate can be followed by a tab character and a description.
+func Completer(complete func(toComplete string) []string) Option {
+	return func(o *options) { o.completer = complete }
+}
+
+// checkSingle checks that the options applicable to multiple flags only are not set for the single flag.
+func (o *options) checkSingle(name string) error {
+	var multipleOnly []string
+	if o.separator != 0 {
+		multipleOnly = append(multipleOnly, "Separator")
+	}
+	if o.minCount != 0 {
+		multipleOnly = append(multipleOnly, "MinCount")
+	}
+	if o.maxCount != 0 {
+		multipleOnly = append(multipleOnly, "MaxCount")
+	}
+	if o.incremental {
+		multipleOnly = append(multipleOnly, "Incremental")
+	}
+	if len(o.allToken) > 0 {
+		multipleOnly = append(multipleOnly, "AllToken")
+	}
+	if len(o.noneToken) > 0 {
+		multipleOnly = append(multipleOnly, "NoneToken")
+	}
+	switch len(multipleOnly) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("option %s is not applicable to single flag -%s", multipleOnly[0], name)
+	default:
+		return fmt.Errorf("options %s are not applicable to single flag -%s", strings.Join(multipleOnly, ", "), name)
+	}
+}
+
+func (o *options) normalize() func(string) string {
+	normalizers := o.normalizers
+	return func(s string) string {
+		for _, normalize := range normalizers {
+			s = normalize(s)
+		}
+		return s
+	}
+}
+
+func foldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < folded {
+				folded = f
+			}
+		}
+		return folded
+	}, s)
+}
@@ -0,0 +1,163 @@
+package flagenum
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Parse parses the command-line flags from os.Args[1:] by the CommandLine.Parse.
+func Parse() {
+	// Ignore errors; CommandLine is set for ExitOnError.
+	_ = CommandLine.Parse(os.Args[1:])
+}
+
+// Parse parses flag definitions from the argument list like the flag.FlagSet.Parse,
+// but sets the flags by values of the configuration file and the environment variables before.
+// The precedence of flag values is: default, configuration file, environment variable, command line argument.
+// An invalid configuration or environment variable value is handled according to the error handling mode of the flag set.
+// If the first argument is the CompleteCommand, the completion candidates of the rest arguments are written to the standard output
+// and the ErrCompletion is handled like the flag.ErrHelp.
+// When parsing finishes, the implied flag values are set, then the allowed values of lazily defined flags with default values
+// are loaded to check the defaults, the required flags, the number of multiple flag values and the flag rules are checked, see Required, MinCount, MaxCount, Requires, Conflicts and Implies.
+// All violations are reported by one error.
+// The error of an invalid enum flag value wraps the cause, so the NotAllowedError can be extracted by the errors.As.
+func (f *FlagSetExt) Parse(arguments []string) error {
+	if len(arguments) > 0 && arguments[0] == CompleteCommand {
+		if err := f.Complete(os.Stdout, arguments[1:]); err != nil {
+			return f.fail(err)
+		}
+		switch f.ErrorHandling() {
+		case flag.ExitOnError:
+			os.Exit(0)
+		case flag.PanicOnError:
+			panic(ErrCompletion)
+		}
+		return ErrCompletion
+	}
+	if err := f.applyConfig(); err != nil {
+		return f.fail(err)
+	}
+	if err := f.applyEnv(); err != nil {
+		return f.fail(err)
+	}
+	f.takeSetFailure()
+	if err := f.FlagSet.Parse(arguments); err != nil {
+		if failure := f.takeSetFailure(); failure != nil && failure.Error() == err.Error() {
+			return failure
+		}
+		return err
+	}
+	f.markArguments(arguments[:len(arguments)-f.NArg()])
+	if err := f.applyRules(); err != nil {
+		return f.fail(err)
+	}
+	if err := f.validate(); err != nil {
+		return f.fail(err)
+	}
+	return nil
+}
+
+// validate checks the enum flag values when parsing finishes, all violations are reported by one error.
+func (f *FlagSetExt) validate() error {
+	var (
+		missing []string
+		errs    []error
+	)
+	f.VisitAll(func(fl *flag.Flag) {
+		if v, ok := fl.Value.(enumValue); ok {
+			if err := v.loadedDefaults(); err != nil {
+				errs = append(errs, err)
+			} else if v.missing() {
+				missing = append(missing, "-"+fl.Name)
+			} else if err := v.validate(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+	errs = append(errs, f.checkRules()...)
+	if len(missing) == 1 {
+		errs = append([]error{fmt.Errorf("missing required flag %s", missing[0])}, errs...)
+	} else if len(missing) > 1 {
+		errs = append([]error{fmt.Errorf("missing required flags %s", strings.Join(missing, ", "))}, errs...)
+	}
+	return errors.Join(errs...)
+}
+
+// takeSetFailure returns the error of the last failed setting of an enum flag value formatted like the flag.FlagSet.Parse does,
+// but wrapping the cause, like the NotAllowedError, and forgets the failures.
+func (f *FlagSetExt) takeSetFailure() error {
+	var failure error
+	f.VisitAll(func(fl *flag.Flag) {
+		if v, ok := fl.Value.(enumValue); ok {
+			if value, err := v.takeFailure(); err != nil {
+				failure = fmt.Errorf("invalid value %q for flag -%s: %w", value, fl.Name, err)
+			}
+		}
+	})
+	return failure
+}
+
+// setPlain sets the value of the flag that is not an enum flag and marks it as set.
+func (f *FlagSetExt) setPlain(name, value string) error {
+	if err := f.FlagSet.Set(name, value); err != nil {
+		return err
+	}
+	f.markSet(name)
+	return nil
+}
+
+// markArguments marks the flags of the parsed arguments as set.
+// The flag.FlagSet.Visit is not used, because it doesn't forget the flags set before the Reset.
+func (f *FlagSetExt) markArguments(parsed []string) {
+	for i := 0; i < len(parsed); i++ {
+		if parsed[i] == "--" {
+			return
+		}
+		fl, _, hasValue := f.lookupArg(parsed[i])
+		if fl == nil {
+			continue
+		}
+		f.markSet(fl.Name)
+		if !hasValue && takesValue(fl) {
+			i++
+		}
+	}
+}
+
+func (f *FlagSetExt) markSet(name string) {
+	if f.plainSet == nil {
+		f.plainSet = map[string]struct{}{}
+	}
+	f.plainSet[name] = void
+}
+
+// Reset restores every enum flag of the set to its default value, so the Parse can be called again.
+// The values of other flags are kept, but they are not treated as set by the rules anymore.
+func (f *FlagSetExt) Reset() {
+	f.plainSet = nil
+	f.VisitAll(func(fl *flag.Flag) {
+		if v, ok := fl.Value.(enumValue); ok {
+			v.reset()
+		}
+	})
+}
+
+// fail reports the error like the flag set does for the command line parsing errors.
+func (f *FlagSetExt) fail(err error) error {
+	fmt.Fprintln(f.Output(), err)
+	if f.Usage == nil {
+		f.defaultUsage()
+	} else {
+		f.Usage()
+	}
+	switch f.ErrorHandling() {
+	case flag.ExitOnError:
+		os.Exit(2)
+	case flag.PanicOnError:
+		panic(err)
+	}
+	return err
+}
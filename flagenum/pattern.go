@@ -0,0 +1,81 @@
+package flagenum
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// pattern is a rule of allowed values.
+type pattern struct {
+	expr     string
+	isRegexp bool
+	glob     string
+	re       *regexp.Regexp
+}
+
+// Glob allows the values of a flag that match the shell file name patterns, like eu-*, in addition to the allowed values.
+// The pattern syntax is defined by the path.Match.
+// Both a pattern and the string form of a value are converted by the normalizers of the matching policy before matching.
+func Glob(patterns ...string) Option {
+	return func(o *options) {
+		for _, expr := range patterns {
+			o.patterns = append(o.patterns, pattern{expr: expr})
+		}
+	}
+}
+
+// Regexp allows the values of a flag that match the regular expressions, like ^[a-z]+-v[0-9]+$, in addition to the allowed values.
+// An expression matches any part of a value, unless it is anchored.
+// An expression is matched to the string form of a value as is, the matching policy is not applied,
+// so a case-insensitive expression needs the (?i) flag.
+func Regexp(expressions ...string) Option {
+	return func(o *options) {
+		for _, expr := range expressions {
+			o.patterns = append(o.patterns, pattern{expr: expr, isRegexp: true})
+		}
+	}
+}
+
+// compile checks the pattern syntax and prepares the glob pattern for matching normalized values.
+func (p pattern) compile(name string, normalize func(string) string) (pattern, error) {
+	if !p.isRegexp {
+		if _, err := path.Match(p.expr, ""); err != nil {
+			return p, fmt.Errorf("invalid glob pattern \"%s\" for flag -%s: %w", p.expr, name, err)
+		}
+		p.glob = normalize(p.expr)
+		return p, nil
+	}
+	re, err := regexp.Compile(p.expr)
+	if err != nil {
+		return p, fmt.Errorf("invalid regexp \"%s\" for flag -%s: %w", p.expr, name, err)
+	}
+	p.re = re
+	return p, nil
+}
+
+// match reports whether the value string s or its normalized form key matches the pattern.
+func (p pattern) match(s, key string) bool {
+	if p.isRegexp {
+		return p.re.MatchString(s)
+	}
+	matched, _ := path.Match(p.glob, key)
+	return matched
+}
+
+// String returns the glob pattern as is and the regular expression enclosed in slashes.
+func (p pattern) String() string {
+	if p.isRegexp {
+		return "/" + p.expr + "/"
+	}
+	return p.expr
+}
+
+func patternsToString(patterns []pattern) string {
+	str := make([]string, len(patterns))
+	for i, p := range patterns {
+		str[i] = p.String()
+	}
+	return strings.Join(str, ",")
+}
@@ -0,0 +1,15 @@
+module github.com/m4gshm/flag/flagenum/protoenum
+
+go 1.20
+
+require (
+	github.com/m4gshm/flag v0.1.0
+	github.com/stretchr/testify v1.9.0
+	google.golang.org/protobuf v1.34.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
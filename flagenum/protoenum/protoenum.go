@@ -0,0 +1,203 @@
+// Package protoenum defines enum flags of generated protobuf enum types.
+// The allowed values are taken from the enum descriptor in the declaration order.
+// The value comments of the proto file describe the values in usage if the descriptor keeps them.
+// The protoc-gen-go drops the comments from the descriptor of the generated code,
+// so they are taken from the files with the source code info given by the Source option.
+package protoenum
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+// Enum is a generated protobuf enum type.
+type Enum interface {
+	~int32
+	protoreflect.Enum
+	String() string
+}
+
+// Option customizes a flag defined by the package functions.
+type Option func(*options)
+
+type options struct {
+	excludeZero bool
+	source      *protoregistry.Files
+	flagOpts    []flagenum.Option
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// ExcludeZero excludes the zero value, usually named like UNSPECIFIED, from the allowed values.
+func ExcludeZero() Option {
+	return func(o *options) { o.excludeZero = true }
+}
+
+// Source sets the proto files with the source code info, the value comments are taken from them.
+// The files can be built by the protodesc.NewFiles from a descriptor set written by the protoc
+// with the --include_source_info and --descriptor_set_out options.
+// The enum must be defined in the files, otherwise the flag definition fails.
+func Source(files *protoregistry.Files) Option {
+	return func(o *options) { o.source = files }
+}
+
+// With applies the flagenum options to the flag.
+// Descriptions given by the flagenum.Descriptions option replace the proto value comments.
+func With(opts ...flagenum.Option) Option {
+	return func(o *options) { o.flagOpts = append(o.flagOpts, opts...) }
+}
+
+// Multiple defines a slice flag of the proto enum type with specified name, default values and usage string.
+// The values of the enum are allowed, an alias of a value defined with the allow_alias option is accepted too.
+// Returns the address of a slice that stores values of the flag and an error if something wrong.
+func Multiple[V Enum](flagSet *flag.FlagSet, name string, defaultValues []V, usage string, opts ...Option) (*[]V, error) {
+	var result []V
+	return &result, MultipleVar(flagSet, &result, name, defaultValues, usage, opts...)
+}
+
+// MultipleVar defines a slice flag of the proto enum type like Multiple.
+// The argument p points to a slice variable in which to store values of the flag.
+// Returns an error if something wrong.
+func MultipleVar[V Enum](flagSet *flag.FlagSet, p *[]V, name string, defaultValues []V, usage string, opts ...Option) error {
+	allowed, flagOpts, err := flagValues[V](name, newOptions(opts...))
+	if err != nil {
+		return err
+	}
+	return flagenum.MultipleVarOf(flagSet, p, name, defaultValues, allowed, usage, flagOpts...)
+}
+
+// Single defines a flag of the proto enum type with specified name, default value and usage string.
+// The values of the enum are allowed, an alias of a value defined with the allow_alias option is accepted too.
+// Returns the address of a variable that stores value of the flag and an error if something wrong.
+func Single[V Enum](flagSet *flag.FlagSet, name string, value V, usage string, opts ...Option) (*V, error) {
+	result := value
+	return &result, SingleVar(flagSet, &result, name, value, usage, opts...)
+}
+
+// SingleVar defines a flag of the proto enum type like Single.
+// The argument p points to a variable in which to store the value of the flag.
+// Returns an error if something wrong.
+func SingleVar[V Enum](flagSet *flag.FlagSet, p *V, name string, value V, usage string, opts ...Option) error {
+	allowed, flagOpts, err := flagValues[V](name, newOptions(opts...))
+	if err != nil {
+		return err
+	}
+	return flagenum.SingleVarOf(flagSet, p, name, value, allowed, usage, flagOpts...)
+}
+
+// Values returns the values of the enum type in the declaration order, the aliases are skipped.
+func Values[V Enum](opts ...Option) []V {
+	var zero V
+	return values[V](describe(zero.Descriptor(), newOptions(opts...).excludeZero))
+}
+
+func values[V Enum](d description) []V {
+	allowed := make([]V, len(d.numbers))
+	for i, n := range d.numbers {
+		allowed[i] = V(n)
+	}
+	return allowed
+}
+
+// flagValues returns the allowed values and the flag options with their aliases and descriptions.
+func flagValues[V Enum](name string, o *options) ([]V, []flagenum.Option, error) {
+	var zero V
+	ed := zero.Descriptor()
+	if o.source != nil {
+		var err error
+		if ed, err = sourceEnum(o.source, ed.FullName(), name); err != nil {
+			return nil, nil, err
+		}
+	}
+	d := describe(ed, o.excludeZero)
+	allowed := values[V](d)
+	var flagOpts []flagenum.Option
+	if len(d.aliases) > 0 {
+		aliases := make(map[string]V, len(d.aliases))
+		for alias, n := range d.aliases {
+			aliases[alias] = V(n)
+		}
+		flagOpts = append(flagOpts, flagenum.Aliases(aliases))
+	}
+	if len(d.descriptions) > 0 {
+		descriptions := make(map[V]string, len(d.descriptions))
+		for n, description := range d.descriptions {
+			descriptions[V(n)] = description
+		}
+		flagOpts = append(flagOpts, flagenum.Descriptions(descriptions))
+	}
+	return allowed, append(flagOpts, o.flagOpts...), nil
+}
+
+// sourceEnum finds the enum descriptor with the source code info in the files.
+func sourceEnum(files *protoregistry.Files, enumName protoreflect.FullName, flagName string) (protoreflect.EnumDescriptor, error) {
+	d, err := files.FindDescriptorByName(enumName)
+	if err != nil {
+		return nil, fmt.Errorf("source enum %s of flag -%s: %w", enumName, flagName, err)
+	}
+	ed, ok := d.(protoreflect.EnumDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("source descriptor %s of flag -%s is not an enum", enumName, flagName)
+	}
+	return ed, nil
+}
+
+type description struct {
+	numbers      []protoreflect.EnumNumber
+	aliases      map[string]protoreflect.EnumNumber
+	descriptions map[protoreflect.EnumNumber]string
+}
+
+// describe reads the enum values, the names of repeated numbers as aliases and the value comments.
+func describe(ed protoreflect.EnumDescriptor, excludeZero bool) description {
+	d := description{}
+	values := ed.Values()
+	locations := ed.ParentFile().SourceLocations()
+	seen := map[protoreflect.EnumNumber]struct{}{}
+	for i := 0; i < values.Len(); i++ {
+		vd := values.Get(i)
+		n := vd.Number()
+		if excludeZero && n == 0 {
+			continue
+		}
+		if _, ok := seen[n]; ok {
+			if d.aliases == nil {
+				d.aliases = map[string]protoreflect.EnumNumber{}
+			}
+			d.aliases[string(vd.Name())] = n
+			continue
+		}
+		seen[n] = struct{}{}
+		d.numbers = append(d.numbers, n)
+		if comment := valueComment(locations.ByDescriptor(vd)); len(comment) > 0 {
+			if d.descriptions == nil {
+				d.descriptions = map[protoreflect.EnumNumber]string{}
+			}
+			d.descriptions[n] = comment
+		}
+	}
+	return d
+}
+
+// valueComment returns the leading or trailing comment of the value joined into one line.
+func valueComment(location protoreflect.SourceLocation) string {
+	comment := location.LeadingComments
+	if len(strings.TrimSpace(comment)) == 0 {
+		comment = location.TrailingComments
+	}
+	return strings.Join(strings.Fields(comment), " ")
+}
@@ -0,0 +1,128 @@
+package protoenum
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+	"google.golang.org/protobuf/types/known/typepb"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Multiple(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	syntaxes, err := Multiple(flag, "syntax", []typepb.Syntax{typepb.Syntax_SYNTAX_PROTO3}, "proto syntax")
+	assert.NoError(t, err)
+	assert.Equal(t, "proto syntax (allowed `any of SYNTAX_PROTO2,SYNTAX_PROTO3,SYNTAX_EDITIONS`)", flag.Lookup("syntax").Usage)
+
+	assert.NoError(t, flag.Parse([]string{"--syntax", "SYNTAX_EDITIONS", "--syntax", "SYNTAX_PROTO2"}))
+	assert.Equal(t, []typepb.Syntax{typepb.Syntax_SYNTAX_EDITIONS, typepb.Syntax_SYNTAX_PROTO2}, *syntaxes)
+}
+
+func Test_Single_ExcludeZero(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	syntax, err := Single(flag, "syntax", typepb.Syntax_SYNTAX_PROTO2, "proto syntax",
+		ExcludeZero(), With(flagenum.Matching(flagenum.IgnoreCase)))
+	assert.NoError(t, err)
+	assert.Equal(t, "proto syntax (allowed `one of SYNTAX_PROTO3,SYNTAX_EDITIONS`)", flag.Lookup("syntax").Usage)
+
+	assert.NoError(t, flag.Parse([]string{"--syntax", "syntax_proto3"}))
+	assert.Equal(t, typepb.Syntax_SYNTAX_PROTO3, *syntax)
+
+	err = flag.Set("syntax", "SYNTAX_PROTO2")
+	assert.EqualError(t, err, "must be one of SYNTAX_PROTO3,SYNTAX_EDITIONS; did you mean \"SYNTAX_PROTO3\"?")
+
+	_, err = Multiple(flag, "syntaxes", []typepb.Syntax{typepb.Syntax_SYNTAX_PROTO2}, "proto syntaxes", ExcludeZero())
+	assert.EqualError(t, err, "unexpected default value \"SYNTAX_PROTO2\" for flag -syntaxes: must be one of SYNTAX_PROTO3,SYNTAX_EDITIONS; did you mean \"SYNTAX_PROTO3\"?")
+}
+
+func Test_Values(t *testing.T) {
+	assert.Equal(t, []typepb.Syntax{typepb.Syntax_SYNTAX_PROTO3, typepb.Syntax_SYNTAX_EDITIONS}, Values[typepb.Syntax](ExcludeZero()))
+}
+
+func Test_describe(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name:    proto.String("Codec"),
+			Options: &descriptorpb.EnumOptions{AllowAlias: proto.Bool(true)},
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("CODEC_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("H264"), Number: proto.Int32(1)},
+				{Name: proto.String("AVC"), Number: proto.Int32(1)},
+				{Name: proto.String("VP9"), Number: proto.Int32(2)},
+			},
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{5, 0, 2, 1}, Span: []int32{1, 2, 10}, LeadingComments: proto.String(" Advanced video coding,\n widely supported.\n")},
+			{Path: []int32{5, 0, 2, 3}, Span: []int32{2, 2, 10}, TrailingComments: proto.String(" Open codec.\n")},
+		}},
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	assert.NoError(t, err)
+
+	d := describe(fd.Enums().Get(0), true)
+	assert.Equal(t, []protoreflect.EnumNumber{1, 2}, d.numbers)
+	assert.Equal(t, map[string]protoreflect.EnumNumber{"AVC": 1}, d.aliases)
+	assert.Equal(t, map[protoreflect.EnumNumber]string{1: "Advanced video coding, widely supported.", 2: "Open codec."}, d.descriptions)
+}
+
+func Test_Usage_Table(t *testing.T) {
+	flags := flagenum.New("test", flag.ContinueOnError)
+	out := &strings.Builder{}
+	flags.SetOutput(out)
+	_, err := Single(flags.FlagSet, "syntax", typepb.Syntax_SYNTAX_PROTO3, "proto syntax",
+		With(flagenum.Descriptions(map[typepb.Syntax]string{typepb.Syntax_SYNTAX_PROTO3: "proto3 syntax"})))
+	assert.NoError(t, err)
+
+	flags.PrintDefaults()
+	assert.Equal(t, `  -syntax value
+    	proto syntax (allowed one of the values below) (default SYNTAX_PROTO3)
+    	  SYNTAX_PROTO2
+    	  SYNTAX_PROTO3    proto3 syntax (default)
+    	  SYNTAX_EDITIONS
+`, out.String())
+}
+
+func Test_Source(t *testing.T) {
+	typeFile := protodesc.ToFileDescriptorProto(typepb.File_google_protobuf_type_proto)
+	syntax := int32(typepb.Syntax(0).Descriptor().Index())
+	typeFile.SourceCodeInfo = &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+		{Path: []int32{5, syntax, 2, 1}, Span: []int32{1, 2, 10}, LeadingComments: proto.String(" Syntax proto3.\n")},
+	}}
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(anypb.File_google_protobuf_any_proto),
+		protodesc.ToFileDescriptorProto(sourcecontextpb.File_google_protobuf_source_context_proto),
+		typeFile,
+	}})
+	assert.NoError(t, err)
+
+	flags := flagenum.New("test", flag.ContinueOnError)
+	out := &strings.Builder{}
+	flags.SetOutput(out)
+	_, err = Single(flags.FlagSet, "syntax", typepb.Syntax_SYNTAX_PROTO2, "proto syntax", Source(files))
+	assert.NoError(t, err)
+
+	flags.PrintDefaults()
+	assert.Equal(t, `  -syntax value
+    	proto syntax (allowed one of the values below) (default SYNTAX_PROTO2)
+    	  SYNTAX_PROTO2
+    	  SYNTAX_PROTO3    Syntax proto3.
+    	  SYNTAX_EDITIONS
+`, out.String())
+
+	_, err = Single(flags.FlagSet, "missing", typepb.Syntax_SYNTAX_PROTO2, "proto syntax", Source(new(protoregistry.Files)))
+	assert.ErrorIs(t, err, protoregistry.NotFound)
+	assert.ErrorContains(t, err, "source enum google.protobuf.Syntax of flag -missing")
+}
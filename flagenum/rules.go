@@ -0,0 +1,175 @@
+package flagenum
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// Condition is a state of a flag checked by the flag rules.
+type Condition struct {
+	name     string
+	value    string
+	anyValue bool
+}
+
+// Is returns the condition that the flag has the value, or contains it if the flag is multiple.
+// The value of an enum flag is matched by the flag matching policy, the default values are taken into account.
+func Is(name, value string) Condition {
+	return Condition{name: name, value: value}
+}
+
+// IsSet returns the condition that the flag is set by the command line, an environment variable or the configuration file.
+func IsSet(name string) Condition {
+	return Condition{name: name, anyValue: true}
+}
+
+func (c Condition) String() string {
+	if c.anyValue {
+		return "-" + c.name
+	}
+	return "-" + c.name + " " + c.value
+}
+
+type ruleKind int
+
+const (
+	requires ruleKind = iota
+	conflicts
+	implies
+)
+
+func (k ruleKind) String() string {
+	switch k {
+	case requires:
+		return "requires"
+	case conflicts:
+		return "conflicts with"
+	default:
+		return "implies"
+	}
+}
+
+type rule struct {
+	kind         ruleKind
+	cond, target Condition
+}
+
+func (r rule) String() string {
+	return r.cond.String() + " " + r.kind.String() + " " + r.target.String()
+}
+
+// Requires declares the command-line flags rule by the CommandLine.Requires.
+func Requires(cond, required Condition) {
+	CommandLine.Requires(cond, required)
+}
+
+// Conflicts declares the command-line flags rule by the CommandLine.Conflicts.
+func Conflicts(cond, conflicting Condition) {
+	CommandLine.Conflicts(cond, conflicting)
+}
+
+// Implies declares the command-line flags rule by the CommandLine.Implies.
+func Implies(cond, implied Condition) {
+	CommandLine.Implies(cond, implied)
+}
+
+// Requires declares that the required condition must be met if the cond is met, like Requires(Is("api", "soap"), IsSet("wsdl")).
+// The rule is checked by the Parse when parsing finishes.
+func (f *FlagSetExt) Requires(cond, required Condition) {
+	f.rules = append(f.rules, rule{kind: requires, cond: cond, target: required})
+}
+
+// Conflicts declares that the conflicting condition must not be met if the cond is met,
+// like Conflicts(Is("log-format", "json"), Is("color", "always")).
+// The rule is checked by the Parse when parsing finishes.
+func (f *FlagSetExt) Conflicts(cond, conflicting Condition) {
+	f.rules = append(f.rules, rule{kind: conflicts, cond: cond, target: conflicting})
+}
+
+// Implies declares that the implied flag value is set if the cond is met, like Implies(Is("mode", "replica"), Is("api", "grpc")).
+// The value is set by the Parse when parsing finishes, unless the implied flag is already set, then the implied condition is checked.
+// The implied condition made by the IsSet is only checked.
+func (f *FlagSetExt) Implies(cond, implied Condition) {
+	f.rules = append(f.rules, rule{kind: implies, cond: cond, target: implied})
+}
+
+// applyRules sets the implied flag values.
+func (f *FlagSetExt) applyRules() error {
+	var errs []error
+	for _, r := range f.rules {
+		if r.kind != implies || r.target.anyValue {
+			continue
+		}
+		met, err := f.met(r.cond)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fl := f.Lookup(r.target.name)
+		if !met || fl == nil || f.isSet(fl) {
+			continue
+		}
+		if v, ok := fl.Value.(enumValue); ok {
+			err = v.apply([]string{r.target.value})
+		} else {
+			err = f.setPlain(fl.Name, r.target.value)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid value \"%s\" for flag -%s implied by %s: %w", r.target.value, fl.Name, r.cond, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkRules returns the errors of all violated rules.
+func (f *FlagSetExt) checkRules() []error {
+	var errs []error
+	for _, r := range f.rules {
+		met, err := f.met(r.cond)
+		if err == nil && met {
+			var targetMet bool
+			if targetMet, err = f.met(r.target); err == nil && targetMet == (r.kind == conflicts) {
+				err = errors.New(r.String())
+			}
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (f *FlagSetExt) met(c Condition) (bool, error) {
+	fl := f.Lookup(c.name)
+	if fl == nil {
+		return false, fmt.Errorf("undefined flag -%s of rule condition %s", c.name, c)
+	}
+	if c.anyValue {
+		return f.isSet(fl), nil
+	}
+	if v, ok := fl.Value.(enumValue); ok {
+		return v.contains(c.value)
+	}
+	return fl.Value.String() == c.value, nil
+}
+
+func (f *FlagSetExt) isSet(fl *flag.Flag) bool {
+	if v, ok := fl.Value.(enumValue); ok {
+		return v.isSet()
+	}
+	_, set := f.plainSet[fl.Name]
+	return set
+}
+
+// printRules prints the rules summary.
+func (f *FlagSetExt) printRules(out io.Writer) {
+	if len(f.rules) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "Rules:")
+	for _, r := range f.rules {
+		fmt.Fprintf(out, "  %s\n", r)
+	}
+}
@@ -0,0 +1,98 @@
+package flagenum
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+// StringerValue is a flag value with the String method, like an enum constant with a generated String method.
+type StringerValue interface {
+	Value
+	fmt.Stringer
+}
+
+// errUnknown is returned by a string converter that cannot find a value by its string form.
+var errUnknown = errors.New("unknown value")
+
+// MultipleOf defines a slice flag of the StringerValue type with specified name, default values, allowed values and usage string.
+// A flag value is matched to the String results of the allowed values, or converted by the UnmarshalText method
+// if the pointer to the type implements the encoding.TextUnmarshaler.
+// Different allowed values rendering the same string are reported as duplicated regardless of the Duplicates option.
+// Returns the address of a slice that stores values of the flag and an error if something wrong.
+func MultipleOf[V StringerValue](flagSet *flag.FlagSet, name string, defaultValues, allowedValues []V, usage string, opts ...Option) (*[]V, error) {
+	var result []V
+	return &result, MultipleVarOf(flagSet, &result, name, defaultValues, allowedValues, usage, opts...)
+}
+
+// MultipleVarOf defines a slice flag of the StringerValue type like MultipleOf.
+// The argument p points to a slice variable in which to store values of the flag.
+// Returns an error if something wrong.
+func MultipleVarOf[V StringerValue](flagSet *flag.FlagSet, p *[]V, name string, defaultValues, allowedValues []V, usage string, opts ...Option) error {
+	if err := checkStrings(name, allowedValues, opts...); err != nil {
+		return err
+	}
+	toVConv, err := stringerConv(name, allowedValues)
+	if err != nil {
+		return err
+	}
+	return MultipleVarE(flagSet, p, name, defaultValues, allowedValues, toVConv, V.String, usage, opts...)
+}
+
+// SingleOf defines a flag of the StringerValue type with specified name, default value, allowed values and usage string.
+// A flag value is matched to the String results of the allowed values, or converted by the UnmarshalText method
+// if the pointer to the type implements the encoding.TextUnmarshaler.
+// Different allowed values rendering the same string are reported as duplicated regardless of the Duplicates option.
+// Returns the address of a variable that stores value of the flag and an error if something wrong.
+func SingleOf[V StringerValue](flagSet *flag.FlagSet, name string, value V, allowedValues []V, usage string, opts ...Option) (*V, error) {
+	result := value
+	return &result, SingleVarOf(flagSet, &result, name, value, allowedValues, usage, opts...)
+}
+
+// SingleVarOf defines a flag of the StringerValue type like SingleOf.
+// The argument p points to a variable in which to store the value of the flag.
+// Returns an error if something wrong.
+func SingleVarOf[V StringerValue](flagSet *flag.FlagSet, p *V, name string, value V, allowedValues []V, usage string, opts ...Option) error {
+	if err := checkStrings(name, allowedValues, opts...); err != nil {
+		return err
+	}
+	toVConv, err := stringerConv(name, allowedValues)
+	if err != nil {
+		return err
+	}
+	return SingleVarE(flagSet, p, name, value, allowedValues, toVConv, V.String, usage, opts...)
+}
+
+// checkStrings checks that different allowed values don't render the same string compared by the matching policy,
+// because such a value could not be selected.
+func checkStrings[V StringerValue](name string, allowedValues []V, opts ...Option) error {
+	normalize := newOptions(opts...).normalize()
+	rendered := make(map[string]V, len(allowedValues))
+	for _, v := range allowedValues {
+		key := normalize(v.String())
+		if other, ok := rendered[key]; !ok {
+			rendered[key] = v
+		} else if other != v {
+			return fmt.Errorf("duplicated allowed value \"%s\" for flag -%s", v, name)
+		}
+	}
+	return nil
+}
+
+// stringerConv returns the string converter by the UnmarshalText method if the type supports it,
+// otherwise the converter doesn't find any value, because the allowed values are matched before conversion.
+func stringerConv[V StringerValue](name string, allowedValues []V) (func(string) (V, error), error) {
+	var zero V
+	if _, ok := any(&zero).(encoding.TextUnmarshaler); ok {
+		return func(s string) (V, error) {
+			var v V
+			err := any(&v).(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			return v, err
+		}, nil
+	}
+	if len(allowedValues) == 0 {
+		return nil, fmt.Errorf("flag -%s of type %T requires allowed values or the encoding.TextUnmarshaler implementation", name, zero)
+	}
+	return func(string) (V, error) { return zero, errUnknown }, nil
+}
@@ -0,0 +1,115 @@
+package flagenum
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// NotAllowedError reports a flag value that doesn't match any allowed value.
+// The error is returned by the Set method of a flag value and wrapped by the default value check errors.
+// The flag.FlagSet.Parse keeps only the error message, but the FlagSetExt.Parse wraps the error.
+type NotAllowedError struct {
+	// Value is the rejected value.
+	Value string
+	// Allowed lists the allowed values.
+	Allowed []string
+	// Patterns lists the patterns of allowed values that the rejected value doesn't match,
+	// a regular expression is enclosed in slashes.
+	Patterns []string
+	// Suggestions lists the allowed values that are close to the rejected one, the closest first.
+	Suggestions []string
+}
+
+var _ error = (*NotAllowedError)(nil)
+
+func (e *NotAllowedError) Error() string {
+	var msg string
+	if len(e.Allowed) > 0 {
+		msg = "must be one of " + strings.Join(e.Allowed, ",")
+	}
+	if patterns := strings.Join(e.Patterns, ","); len(patterns) > 0 && len(msg) > 0 {
+		msg += " or match " + patterns
+	} else if len(patterns) > 0 {
+		msg = "must match " + patterns
+	}
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf("; did you mean \"%s\"?", e.Suggestions[0])
+	}
+	return msg
+}
+
+// suggest ranks the allowed values by closeness of their names to the value.
+// The names of an allowed value are the corresponding candidates, such as the value string and its aliases.
+// An allowed value is close if one of its names differs only by letter case
+// or is within an edit distance of a third of the value length.
+func suggest(value string, allowed []string, candidates [][]string) []string {
+	type suggestion struct {
+		value    string
+		distance int
+	}
+	var (
+		folded      = foldCase(value)
+		maxDistance = utf8.RuneCountInString(value) / 3
+		suggestions []suggestion
+	)
+	for i, names := range candidates {
+		distance := -1
+		for _, name := range names {
+			d := 0
+			if foldCase(name) != folded {
+				if d = editDistance(value, name); d > maxDistance {
+					continue
+				}
+			}
+			if distance < 0 || d < distance {
+				distance = d
+			}
+		}
+		if distance >= 0 {
+			suggestions = append(suggestions, suggestion{allowed[i], distance})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool { return suggestions[i].distance < suggestions[j].distance })
+	result := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = s.value
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// editDistance calculates the optimal string alignment distance between the strings,
+// that is the number of rune insertions, deletions, substitutions and transpositions of adjacent runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2, prev, cur := make([]int, len(rb)+1), make([]int, len(rb)+1), make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, minInt(cur[j-1]+1, prev[j-1]+cost))
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
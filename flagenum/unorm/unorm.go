@@ -0,0 +1,21 @@
+// Package unorm provides the Unicode normalization forms as normalizers of the flagenum matching policy.
+// A value and the allowed values are compared in the same normalization form, so a precomposed character
+// matches the decomposed one, like é and e followed by the combining acute accent.
+package unorm
+
+import (
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+var (
+	// NFC matches values in the canonical composition form.
+	NFC flagenum.Normalizer = norm.NFC.String
+	// NFD matches values in the canonical decomposition form.
+	NFD flagenum.Normalizer = norm.NFD.String
+	// NFKC matches values in the compatibility composition form, like the ligature ﬁ and the letters fi.
+	NFKC flagenum.Normalizer = norm.NFKC.String
+	// NFKD matches values in the compatibility decomposition form.
+	NFKD flagenum.Normalizer = norm.NFKD.String
+)
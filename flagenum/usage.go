@@ -0,0 +1,132 @@
+package flagenum
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// enumValue is implemented by the values of flags defined by the package.
+type enumValue interface {
+	EnumValue
+	// flagUsage returns the flag usage, without the allowed values list if they are printed by a table.
+	flagUsage(table bool) string
+	// describeValues returns rows of the allowed values description table or nil.
+	describeValues() []valueDescription
+	// valueRows returns the allowed values with their aliases and descriptions.
+	valueRows() []valueDescription
+	// completeValues returns the completion candidates of a value started by the toComplete string
+	// and the prefix of the candidates, the values given to a multiple flag before are excluded.
+	completeValues(toComplete string, given []string) (prefix string, candidates []valueDescription)
+	// baseUsage returns the usage string specified on the flag definition.
+	baseUsage() string
+	// separatorRune returns the separator of multiple flag elements or zero if the flag has no one.
+	separatorRune() rune
+	// apply sets the flag by values taken from a source like an environment variable.
+	apply(elements []string) error
+	// missing reports whether the flag is required, but not set.
+	missing() bool
+	// isSet reports whether the flag is set by the command line, an environment variable or the configuration file.
+	isSet() bool
+	// contains reports whether the flag value is or contains the value of the string s.
+	contains(s string) (bool, error)
+	// validate checks the flag value when parsing finishes.
+	validate() error
+	// loaded calls the allowed values provider of a lazily defined flag once and returns its error.
+	loaded() error
+	// loadedDefaults calls the allowed values provider of a lazily defined flag if there are default values to check.
+	loadedDefaults() error
+	// reset restores the default value of the flag.
+	reset()
+	// takeFailure returns the value and the error of the last failed Set call and forgets them.
+	takeFailure() (value string, err error)
+}
+
+type valueDescription struct {
+	value       string
+	aliases     []string
+	description string
+	isDefault   bool
+}
+
+// PrintDefaults prints the default values of all defined command-line flags by the CommandLine.PrintDefaults.
+func PrintDefaults() {
+	CommandLine.PrintDefaults()
+}
+
+// Usage prints a usage message documenting all defined command-line flags like the flag.Usage does,
+// but using the CommandLine.PrintDefaults. It can be assigned to the flag.Usage variable.
+func Usage() {
+	fmt.Fprintf(CommandLine.Output(), "Usage of %s:\n", os.Args[0])
+	PrintDefaults()
+}
+
+// PrintDefaults prints, to the flag set output, the default values of all defined flags in the flag.FlagSet.PrintDefaults format.
+// Described allowed values of a flag are printed as an indented table under the flag, the default values are marked.
+// The flag rules are summarized after the flags.
+// The allowed values of a flag defined with a provider function are taken by calling the provider.
+func (f *FlagSetExt) PrintDefaults() {
+	out := f.Output()
+	f.VisitAll(func(fl *flag.Flag) {
+		usage, table := fl.Usage, []valueDescription(nil)
+		if v, ok := fl.Value.(enumValue); ok {
+			table = v.describeValues()
+			usage = v.flagUsage(len(table) > 0)
+			if err := v.loaded(); err != nil {
+				usage += getSuffix(usage, "allowed values unavailable: "+err.Error())
+			}
+		}
+		if env := f.envName(fl); len(env) > 0 {
+			usage += getSuffix(usage, "env "+env)
+		}
+		printFlag(out, fl, usage)
+		printTable(out, table)
+	})
+	f.printRules(out)
+}
+
+func (f *FlagSetExt) defaultUsage() {
+	if name := f.Name(); name == "" {
+		fmt.Fprintf(f.Output(), "Usage:\n")
+	} else {
+		fmt.Fprintf(f.Output(), "Usage of %s:\n", name)
+	}
+	f.PrintDefaults()
+}
+
+// printFlag prints the flag with the specified usage by the flag package formatter.
+func printFlag(out io.Writer, fl *flag.Flag, usage string) {
+	single := flag.NewFlagSet("", flag.ContinueOnError)
+	single.SetOutput(out)
+	single.Var(fl.Value, fl.Name, usage)
+	single.Lookup(fl.Name).DefValue = fl.DefValue
+	single.PrintDefaults()
+}
+
+func printTable(out io.Writer, rows []valueDescription) {
+	values := make([]string, len(rows))
+	width := 0
+	for i, row := range rows {
+		values[i] = strings.Join(append([]string{row.value}, row.aliases...), "|")
+		if w := utf8.RuneCountInString(values[i]); w > width {
+			width = w
+		}
+	}
+	for i, row := range rows {
+		line := strings.Builder{}
+		line.WriteString("    \t  ")
+		line.WriteString(values[i])
+		description := row.description
+		if row.isDefault {
+			description = strings.TrimLeft(description+" (default)", " ")
+		}
+		if len(description) > 0 {
+			line.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(values[i])+2))
+			line.WriteString(description)
+		}
+		fmt.Fprintln(out, line.String())
+	}
+}
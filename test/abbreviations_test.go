@@ -0,0 +1,88 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Abbreviations(t *testing.T) {
+	type testCase struct {
+		name      string
+		arguments []string
+		expected  []string
+		parseErr  error
+	}
+
+	tests := []testCase{
+		{
+			name:      "unique prefixes",
+			arguments: []string{"gr", "so"},
+			expected:  []string{"grpc", "soap"},
+		},
+		{
+			name:      "full value",
+			arguments: []string{"sse"},
+			expected:  []string{"sse"},
+		},
+		{
+			name:      "alias prefix",
+			arguments: []string{"ht"},
+			expected:  []string{"rest"},
+		},
+		{
+			name:      "case insensitive prefix",
+			arguments: []string{"GR"},
+			expected:  []string{"grpc"},
+		},
+		{
+			name:      "ambiguous prefix",
+			arguments: []string{"s"},
+			parseErr:  fmt.Errorf("invalid value \"s\" for flag -val: \"s\" is ambiguous: soap, sse"),
+		},
+		{
+			name:      "duplicated by prefix",
+			arguments: []string{"grpc", "g"},
+			parseErr:  fmt.Errorf("invalid value \"g\" for flag -val: duplicated value \"grpc\" for flag -val"),
+		},
+		{
+			name:      "unknown",
+			arguments: []string{"x"},
+			parseErr:  fmt.Errorf("invalid value \"x\" for flag -val: must be one of rest,grpc,soap,sse"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			selected, err := flagenum.Multiple(flag, "val", nil, []string{"rest", "grpc", "soap", "sse"}, strAsIs, strAsIs, "enumerated parameter",
+				flagenum.Abbreviations(), flagenum.Aliases(map[string]string{"http": "rest"}), flagenum.Matching(flagenum.IgnoreCase))
+			assert.NoError(t, err)
+
+			a := []string{}
+			for _, arg := range test.arguments {
+				a = append(a, "--val", arg)
+			}
+			err = flag.Parse(a)
+			if test.parseErr != nil {
+				assert.EqualError(t, err, test.parseErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, *selected)
+			}
+		})
+	}
+}
+
+func Test_Single_Abbreviations(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	selected, err := flagenum.Single(flag, "val", "info", []string{"debug", "info"}, strAsIs, strAsIs, "logger level", flagenum.Abbreviations())
+	assert.NoError(t, err)
+
+	assert.NoError(t, flag.Parse([]string{"--val", "deb"}))
+	assert.Equal(t, "debug", *selected)
+}
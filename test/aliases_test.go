@@ -0,0 +1,68 @@
+package test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Single_Aliases(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	var selected string
+	err := flagenum.SingleVar(flag, &selected, "val", "warning", []string{"info", "warning"}, strAsIs, strAsIs, "enumerated parameter",
+		flagenum.Aliases(map[string]string{"warn": "warning", "w": "warning"}))
+	assert.NoError(t, err)
+
+	assert.NoError(t, flag.Parse([]string{"--val", "warn"}))
+	assert.Equal(t, "warning", selected)
+
+	err = flag.Parse([]string{"--val", "i"})
+	assert.EqualError(t, err, "invalid value \"i\" for flag -val: must be one of info,warning")
+}
+
+func Test_Multiple_Aliases(t *testing.T) {
+	newFlag := func() (*flag.FlagSet, *[]string) {
+		flag := flag.NewFlagSet("test", flag.ContinueOnError)
+		var selected []string
+		err := flagenum.MultipleVar(flag, &selected, "val", nil, []string{"rest", "grpc-v2"}, strAsIs, strAsIs, "enumerated parameter",
+			flagenum.Aliases(map[string]string{"http": "rest", "grpc": "grpc-v2"}), flagenum.Matching(flagenum.IgnoreCase))
+		assert.NoError(t, err)
+		return flag, &selected
+	}
+
+	flag, selected := newFlag()
+	assert.NoError(t, flag.Parse([]string{"--val", "HTTP", "--val", "grpc"}))
+	assert.Equal(t, []string{"rest", "grpc-v2"}, *selected)
+
+	flag, _ = newFlag()
+	err := flag.Parse([]string{"--val", "rest", "--val", "http"})
+	assert.EqualError(t, err, "invalid value \"http\" for flag -val: duplicated value \"rest\" for flag -val")
+}
+
+func Test_Aliases_Errors(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err := flagenum.Single(flag, "val", "", []string{"info", "warning"}, strAsIs, strAsIs, "", flagenum.Aliases(map[string]string{"warn": "warnin"}))
+	assert.EqualError(t, err, "unexpected value \"warnin\" of alias \"warn\" for flag -val: must be one of info,warning; did you mean \"warning\"?")
+
+	_, err = flagenum.Single(flag, "val", "", []string{"info", "warning"}, strAsIs, strAsIs, "", flagenum.Aliases(map[string]string{"info": "warning"}))
+	assert.EqualError(t, err, "duplicated alias \"info\" for flag -val")
+
+	_, err = flagenum.Single(flag, "val", "", []string{"info", "warning"}, strAsIs, strAsIs, "", flagenum.Aliases(map[string]int{"info": 1}))
+	assert.EqualError(t, err, "aliases type map[string]int doesn't match to flag -val values")
+}
+
+func Test_Aliases_Usage(t *testing.T) {
+	out := &strings.Builder{}
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	flag.SetOutput(out)
+	_, _ = flagenum.Single(flag, "val", "info", []string{"info", "warning"}, strAsIs, strAsIs, "enumerated parameter",
+		flagenum.Aliases(map[string]string{"warn": "warning", "w": "warning"}))
+
+	flag.Usage()
+
+	assert.Equal(t, "Usage of test:\n  -val one of info,warning|w|warn\n    \tenumerated parameter (allowed one of info,warning|w|warn) (default info)\n", out.String())
+}
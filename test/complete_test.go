@@ -0,0 +1,136 @@
+package test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Complete(t *testing.T) {
+	type testCase struct {
+		name     string
+		args     []string
+		expected string
+	}
+
+	tests := []testCase{
+		{
+			name:     "flag names",
+			args:     []string{"--"},
+			expected: "--api\tenabled api engine\n--log-level\tlogger level\n--plugin\tplugin name\n--verbose\tverbose output\n:4\n",
+		},
+		{
+			name:     "flag name prefix",
+			args:     []string{"-l"},
+			expected: "-log-level\tlogger level\n:4\n",
+		},
+		{
+			name:     "allowed values",
+			args:     []string{"--log-level", ""},
+			expected: "debug\ninfo\nwarn\n:4\n",
+		},
+		{
+			name:     "allowed values prefix",
+			args:     []string{"--log-level", "d"},
+			expected: "debug\n:4\n",
+		},
+		{
+			name:     "described values without given",
+			args:     []string{"--api", "rest", "-verbose", "--api=soap", "--api", ""},
+			expected: "grpc\tgRPC engine\n:4\n",
+		},
+		{
+			name:     "value after equal sign",
+			args:     []string{"--api=g"},
+			expected: "--api=grpc\tgRPC engine\n:4\n",
+		},
+		{
+			name:     "completer",
+			args:     []string{"--plugin", "a"},
+			expected: "auth\tauthentication\naudit\n:4\n",
+		},
+		{
+			name:     "argument",
+			args:     []string{"-verbose", "file"},
+			expected: ":0\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := flagenum.New("app", flag.ContinueOnError)
+			flags.MultipleStrings("api", nil, []string{"rest", "grpc", "soap"}, "enabled api engine",
+				flagenum.Descriptions(map[string]string{"rest": "REST over HTTP", "grpc": "gRPC engine"}))
+			flags.SingleString("log-level", "info", []string{"debug", "info", "warn"}, "logger level")
+			flags.SingleString("plugin", "", nil, "plugin name", flagenum.Completer(func(toComplete string) []string {
+				var candidates []string
+				for _, plugin := range []string{"auth\tauthentication", "audit", "cache"} {
+					if strings.HasPrefix(plugin, toComplete) {
+						candidates = append(candidates, plugin)
+					}
+				}
+				return candidates
+			}))
+			flags.Bool("verbose", false, "verbose output")
+
+			out := &strings.Builder{}
+			assert.NoError(t, flags.Complete(out, test.args))
+			assert.Equal(t, test.expected, out.String())
+		})
+	}
+}
+
+func Test_Complete_Parse(t *testing.T) {
+	flags := flagenum.New("app", flag.ContinueOnError)
+	flags.SingleString("log-level", "info", []string{"debug", "info"}, "logger level")
+
+	err := flags.Parse([]string{flagenum.CompleteCommand, "--log-level", "zzz"})
+	assert.ErrorIs(t, err, flagenum.ErrCompletion)
+}
+
+func Test_Complete_Given_Values(t *testing.T) {
+	type testCase struct {
+		name     string
+		args     []string
+		expected string
+	}
+
+	tests := []testCase{
+		{
+			name:     "separated given",
+			args:     []string{"--api", "rest,grpc", "--api", ""},
+			expected: "soap\n:4\n",
+		},
+		{
+			name:     "matching policy",
+			args:     []string{"--api", "REST", "--api", ""},
+			expected: "grpc\nsoap\n:4\n",
+		},
+		{
+			name:     "last element",
+			args:     []string{"--api", "rest,"},
+			expected: "rest,grpc\nrest,soap\n:4\n",
+		},
+		{
+			name:     "last element prefix",
+			args:     []string{"--api=Grpc,s"},
+			expected: "--api=Grpc,soap\n:4\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := flagenum.New("app", flag.ContinueOnError)
+			flags.MultipleStrings("api", nil, []string{"rest", "grpc", "soap"}, "enabled api engine",
+				flagenum.Separator(','), flagenum.Matching(flagenum.IgnoreCase))
+
+			out := &strings.Builder{}
+			assert.NoError(t, flags.Complete(out, test.args))
+			assert.Equal(t, test.expected, out.String())
+		})
+	}
+}
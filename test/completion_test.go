@@ -0,0 +1,44 @@
+package test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func Test_Completion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			flags := flagenum.New("app", flag.ContinueOnError)
+			flags.MultipleStrings("api", []string{"rest"}, []string{"rest", "grpc", "soap"}, "enabled api engine",
+				flagenum.Descriptions(map[string]string{"rest": "REST over HTTP", "grpc": "gRPC engine"}))
+			flags.SingleString("log-level", "info", []string{"debug", "info", "warn"}, "logger level")
+			flags.String("config", "", "config `file`")
+			flags.Bool("verbose", false, "verbose output")
+
+			out := &strings.Builder{}
+			assert.NoError(t, flags.Completion(out, shell))
+
+			golden := filepath.Join("testdata", "completion."+shell)
+			if *updateGolden {
+				assert.NoError(t, os.WriteFile(golden, []byte(out.String()), 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), out.String())
+		})
+	}
+}
+
+func Test_Completion_UnsupportedShell(t *testing.T) {
+	flags := flagenum.New("app", flag.ContinueOnError)
+	assert.EqualError(t, flags.Completion(&strings.Builder{}, "powershell"), "unsupported shell \"powershell\"")
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Config(t *testing.T) {
+	type testCase struct {
+		name      string
+		file      string
+		content   string
+		decoder   flagenum.Decoder
+		env       map[string]string
+		arguments []string
+		api       []string
+		logLevel  string
+		port      int
+		parseErr  string
+	}
+
+	tests := []testCase{
+		{
+			name:     "json",
+			file:     "config.json",
+			content:  `{"api": ["grpc", "soap"], "log-level": "debug", "server": {"port": 8080}}`,
+			decoder:  json.Unmarshal,
+			api:      []string{"grpc", "soap"},
+			logLevel: "debug",
+			port:     8080,
+		},
+		{
+			name:     "yaml",
+			file:     "config.yaml",
+			content:  "api: [grpc]\nlog-level: warn\nserver:\n  port: 9090\n",
+			decoder:  yaml.Unmarshal,
+			api:      []string{"grpc"},
+			logLevel: "warn",
+			port:     9090,
+		},
+		{
+			name:      "env and command line override file",
+			file:      "config.yaml",
+			content:   "api: [grpc, soap]\nlog-level: warn\n",
+			decoder:   yaml.Unmarshal,
+			env:       map[string]string{"API": "soap"},
+			arguments: []string{"--log-level", "debug"},
+			api:       []string{"soap"},
+			logLevel:  "debug",
+		},
+		{
+			name:     "bad value",
+			file:     "config.yaml",
+			content:  "api: [grpc, http]\n",
+			decoder:  yaml.Unmarshal,
+			parseErr: "config.yaml: invalid value [grpc http] of key \"api\" for flag -api: element \"http\": must be one of rest,grpc,soap",
+		},
+		{
+			name:     "duplicated value",
+			file:     "config.json",
+			content:  `{"api": ["grpc", "grpc"]}`,
+			decoder:  json.Unmarshal,
+			parseErr: "config.json: invalid value [grpc grpc] of key \"api\" for flag -api: element \"grpc\": duplicated value \"grpc\" for flag -api",
+		},
+		{
+			name:     "array for single flag",
+			file:     "config.json",
+			content:  `{"log-level": ["warn", "info"]}`,
+			decoder:  json.Unmarshal,
+			parseErr: "config.json: invalid value [warn info] of key \"log-level\" for flag -log-level: expected one value, got 2",
+		},
+		{
+			name:     "no decoder",
+			file:     "config.json",
+			content:  `{"log-level": "warn"}`,
+			parseErr: "config.json: no decoder of the configuration file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			path := filepath.Join(t.TempDir(), test.file)
+			assert.NoError(t, os.WriteFile(path, []byte(test.content), 0o600))
+
+			flags := flagenum.New("test", flag.ContinueOnError)
+			flags.SetOutput(&strings.Builder{})
+			api := flags.MultipleStrings("api", []string{"rest"}, []string{"rest", "grpc", "soap"}, "enabled api engine")
+			logLevel := flags.SingleString("log-level", "info", []string{"debug", "info", "warn"}, "logger level")
+			port := flags.Int("port", 0, "server port")
+			flags.ConfigFile(path, test.decoder)
+			flags.ConfigKey("port", "server.port")
+			flags.BindEnv("api", "API")
+
+			err := flags.Parse(test.arguments)
+			if len(test.parseErr) > 0 {
+				assert.EqualError(t, err, filepath.Join(filepath.Dir(path), test.parseErr))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.api, *api)
+				assert.Equal(t, test.logLevel, *logLevel)
+				assert.Equal(t, test.port, *port)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Multiple_Count(t *testing.T) {
+	type testCase struct {
+		name     string
+		defaults []string
+		args     []string
+		expected []string
+		err      string
+	}
+
+	tests := []testCase{
+		{
+			name:     "within bounds",
+			args:     []string{"--api", "rest", "--api", "grpc"},
+			expected: []string{"rest", "grpc"},
+		},
+		{
+			name: "too few",
+			err:  "flag -api requires at least 1 value",
+		},
+		{
+			name:     "defaults counted",
+			defaults: []string{"soap"},
+			expected: []string{"soap"},
+		},
+		{
+			name: "too many",
+			args: []string{"--api", "rest", "--api", "grpc", "--api", "soap", "--api", "ws"},
+			err:  "flag -api accepts at most 3 values",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := flagenum.New("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			api := flags.MultipleStrings("api", test.defaults, []string{"rest", "grpc", "soap", "ws"}, "enabled api engine",
+				flagenum.MinCount(1), flagenum.MaxCount(3))
+			assert.Equal(t, "enabled api engine (allowed `1 to 3 of rest,grpc,soap,ws`)", flags.Lookup("api").Usage)
+
+			err := flags.Parse(test.args)
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, *api)
+		})
+	}
+}
+
+func Test_Multiple_Count_Usage(t *testing.T) {
+	type testCase struct {
+		name     string
+		allowed  []string
+		opts     []flagenum.Option
+		expected string
+		err      string
+	}
+
+	tests := []testCase{
+		{name: "min", allowed: []string{"a", "b"}, opts: []flagenum.Option{flagenum.MinCount(2)}, expected: "values (allowed `at least 2 of a,b`)"},
+		{name: "max", allowed: []string{"a", "b"}, opts: []flagenum.Option{flagenum.MaxCount(1)}, expected: "values (allowed `at most 1 of a,b`)"},
+		{name: "exact", opts: []flagenum.Option{flagenum.MinCount(2), flagenum.MaxCount(2)}, expected: "values (2 values)"},
+		{name: "invalid", opts: []flagenum.Option{flagenum.MinCount(3), flagenum.MaxCount(2)}, err: "invalid value count bounds 3..2 for flag -val"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := flagenum.Multiple(flag, "val", nil, test.allowed, strAsIs, strAsIs, "values", test.opts...)
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, flag.Lookup("val").Usage)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Multiple_Duplicates(t *testing.T) {
+	type testCase struct {
+		name     string
+		policy   flagenum.DuplicatePolicy
+		defaults []string
+		initial  []string
+		args     []string
+		expected []string
+		err      string
+	}
+
+	allowed := []string{"auth", "log", "gzip", "log"}
+	tests := []testCase{
+		{
+			name:   "error",
+			policy: flagenum.DuplicateError,
+			err:    "duplicated allowed value \"log\" for flag -mw",
+		},
+		{
+			name:     "ignore",
+			policy:   flagenum.DuplicateIgnore,
+			defaults: []string{"log", "log"},
+			initial:  []string{"log"},
+			args:     []string{"--mw", "auth", "--mw", "log", "--mw", "auth"},
+			expected: []string{"auth", "log"},
+		},
+		{
+			name:     "keep",
+			policy:   flagenum.DuplicateKeep,
+			defaults: []string{"log", "log"},
+			initial:  []string{"log", "log"},
+			args:     []string{"--mw", "log", "--mw", "auth", "--mw", "log"},
+			expected: []string{"log", "auth", "log"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			var selected []string
+			err := flagenum.MultipleVar(flag, &selected, "mw", test.defaults, allowed, strAsIs, strAsIs, "middleware chain",
+				flagenum.Duplicates(test.policy))
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.initial, selected)
+			assert.Equal(t, "middleware chain (allowed `any of auth,log,gzip`)", flag.Lookup("mw").Usage)
+
+			assert.NoError(t, flag.Parse(test.args))
+			assert.Equal(t, test.expected, selected)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Env(t *testing.T) {
+	type testCase struct {
+		name      string
+		env       map[string]string
+		arguments []string
+		api       []string
+		logLevel  string
+		parseErr  string
+	}
+
+	tests := []testCase{
+		{
+			name:     "defaults",
+			api:      []string{"rest"},
+			logLevel: "info",
+		},
+		{
+			name:     "env values",
+			env:      map[string]string{"APP_API": "grpc,soap", "LEVEL": "debug"},
+			api:      []string{"grpc", "soap"},
+			logLevel: "debug",
+		},
+		{
+			name:      "command line overrides env",
+			env:       map[string]string{"APP_API": "grpc,soap", "LEVEL": "debug"},
+			arguments: []string{"--api", "rest", "--log-level", "warn"},
+			api:       []string{"rest"},
+			logLevel:  "warn",
+		},
+		{
+			name:     "empty multiple env",
+			env:      map[string]string{"APP_API": ""},
+			api:      []string{},
+			logLevel: "info",
+		},
+		{
+			name:     "bad env value",
+			env:      map[string]string{"APP_API": "grpc,http"},
+			parseErr: "invalid value \"grpc,http\" for flag -api from environment variable APP_API: element \"http\": must be one of rest,grpc,soap",
+		},
+		{
+			name:     "duplicated env value",
+			env:      map[string]string{"APP_API": "grpc,grpc"},
+			parseErr: "invalid value \"grpc,grpc\" for flag -api from environment variable APP_API: element \"grpc\": duplicated value \"grpc\" for flag -api",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			flags := flagenum.New("test", flag.ContinueOnError)
+			flags.SetOutput(&strings.Builder{})
+			api := flags.MultipleStrings("api", []string{"rest"}, []string{"rest", "grpc", "soap"}, "enabled api engine")
+			logLevel := flags.SingleString("log-level", "info", []string{"debug", "info", "warn"}, "logger level")
+			flags.AutoEnv("APP")
+			flags.BindEnv("log-level", "LEVEL")
+
+			err := flags.Parse(test.arguments)
+			if len(test.parseErr) > 0 {
+				assert.EqualError(t, err, test.parseErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.api, *api)
+				assert.Equal(t, test.logLevel, *logLevel)
+			}
+		})
+	}
+}
+
+func Test_Env_Separator(t *testing.T) {
+	t.Setenv("API", `grpc;"a;b"`)
+	flags := flagenum.New("test", flag.ContinueOnError)
+	api := flags.MultipleStrings("api", nil, nil, "enabled api engine")
+	flags.AutoEnv("")
+	flags.EnvSeparator(';')
+
+	assert.NoError(t, flags.Parse(nil))
+	assert.Equal(t, []string{"grpc", "a;b"}, *api)
+}
+
+func Test_Env_Flag_Separator(t *testing.T) {
+	t.Setenv("API", "grpc;soap")
+	t.Setenv("PORTS", "80,443")
+	flags := flagenum.New("test", flag.ContinueOnError)
+	api := flags.MultipleStrings("api", nil, []string{"rest", "grpc", "soap"}, "enabled api engine", flagenum.Separator(';'))
+	ports := flags.MultipleStrings("ports", nil, nil, "ports")
+	flags.AutoEnv("")
+
+	assert.NoError(t, flags.Parse(nil))
+	assert.Equal(t, []string{"grpc", "soap"}, *api)
+	assert.Equal(t, []string{"80", "443"}, *ports)
+}
+
+func Test_Env_Usage(t *testing.T) {
+	out := &strings.Builder{}
+	flags := flagenum.New("test", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.SingleString("logLevel", "info", []string{"debug", "info"}, "logger level")
+	flags.String("name", "", "application name")
+	flags.AutoEnv("APP")
+	flags.BindEnv("name", "NAME")
+
+	flags.Usage()
+
+	assert.Equal(t, `Usage of test:
+  -logLevel one of debug,info
+    	logger level (allowed one of debug,info) (env APP_LOG_LEVEL) (default info)
+  -name string
+    	application name (env NAME)
+`, out.String())
+}
@@ -0,0 +1,107 @@
+package test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Multiple_Incremental(t *testing.T) {
+	type testCase struct {
+		name     string
+		args     []string
+		expected []string
+		err      string
+	}
+
+	tests := []testCase{
+		{
+			name:     "defaults",
+			expected: []string{"rest", "grpc"},
+		},
+		{
+			name:     "add",
+			args:     []string{"--api", "+soap"},
+			expected: []string{"rest", "grpc", "soap"},
+		},
+		{
+			name:     "remove",
+			args:     []string{"--api", "-grpc"},
+			expected: []string{"rest"},
+		},
+		{
+			name:     "add and remove separated",
+			args:     []string{"--api", "-rest,+soap,+ws"},
+			expected: []string{"grpc", "soap", "ws"},
+		},
+		{
+			name:     "replace",
+			args:     []string{"--api", "soap", "--api", "ws"},
+			expected: []string{"soap", "ws"},
+		},
+		{
+			name:     "replace then add",
+			args:     []string{"--api", "soap", "--api", "+ws"},
+			expected: []string{"soap", "ws"},
+		},
+		{
+			name:     "remove then add again",
+			args:     []string{"--api", "-rest", "--api", "+rest"},
+			expected: []string{"grpc", "rest"},
+		},
+		{
+			name:     "remove then replace",
+			args:     []string{"--api", "-grpc", "--api", "rest"},
+			expected: []string{"rest"},
+		},
+		{
+			name:     "add then replace",
+			args:     []string{"--api", "+soap", "--api", "ws"},
+			expected: []string{"soap", "ws"},
+		},
+		{
+			name:     "remove, add again then replace",
+			args:     []string{"--api", "-rest,+rest,soap"},
+			expected: []string{"rest", "soap"},
+		},
+		{
+			name: "add duplicate",
+			args: []string{"--api", "+grpc"},
+			err:  "invalid value \"+grpc\" for flag -api: duplicated value \"grpc\" for flag -api",
+		},
+		{
+			name: "remove not allowed",
+			args: []string{"--api", "-graphql"},
+			err:  "invalid value \"-graphql\" for flag -api: must be one of rest,grpc,soap,ws",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			var selected []string
+			err := flagenum.MultipleVar(flag, &selected, "api", []string{"rest", "grpc"}, []string{"rest", "grpc", "soap", "ws"}, strAsIs, strAsIs,
+				"enabled api engine", flagenum.Incremental(), flagenum.Separator(','))
+			assert.NoError(t, err)
+			assert.Equal(t, "enabled api engine (allowed `any of rest,grpc,soap,ws`) (+value adds to defaults, -value removes)",
+				flag.Lookup("api").Usage)
+
+			err = flag.Parse(test.args)
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, selected)
+		})
+	}
+}
+
+func Test_Multiple_Incremental_Allowed(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err := flagenum.Multiple(flag, "level", nil, []string{"0", "-1"}, strAsIs, strAsIs, "levels", flagenum.Incremental())
+	assert.EqualError(t, err, "allowed value \"-1\" of flag -level conflicts with the incremental syntax")
+}
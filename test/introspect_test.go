@@ -0,0 +1,44 @@
+package test
+
+import (
+	"flag"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_EnumFlags(t *testing.T) {
+	flags := flagenum.New("test", flag.ContinueOnError)
+	flags.MultipleStrings("api", []string{"rest"}, []string{"rest", "grpc"}, "enabled api engine")
+	flags.SingleString("log-level", "", []string{"debug", "info"}, "logger level")
+	_, err := flagenum.Single(flags.FlagSet, "retry", 3, nil, func(s string) int { v, _ := strconv.Atoi(s); return v }, strconv.Itoa, "retry count")
+	assert.NoError(t, err)
+	flags.Duration("timeout", time.Second, "plain flag")
+
+	type enumFlag struct {
+		name     string
+		allowed  []string
+		defaults []string
+		multiple bool
+	}
+	var visited []enumFlag
+	flags.EnumFlags(func(fl *flag.Flag, v flagenum.EnumValue) {
+		assert.Equal(t, fl.Name, v.Name())
+		visited = append(visited, enumFlag{name: v.Name(), allowed: v.Allowed(), defaults: v.Defaults(), multiple: v.IsMultiple()})
+	})
+
+	assert.Equal(t, []enumFlag{
+		{name: "api", allowed: []string{"rest", "grpc"}, defaults: []string{"rest"}, multiple: true},
+		{name: "log-level", allowed: []string{"debug", "info"}},
+		{name: "retry", defaults: []string{"3"}},
+	}, visited)
+
+	v, ok := flags.Lookup("retry").Value.(flagenum.EnumValue)
+	assert.True(t, ok)
+	assert.NoError(t, flags.Parse([]string{"-retry", "5"}))
+	assert.Equal(t, 5, *v.Get().(*int))
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+	"github.com/m4gshm/flag/flagenum/unorm"
+)
+
+func Test_Single_Matching(t *testing.T) {
+	type testCase struct {
+		name          string
+		matching      []flagenum.Normalizer
+		defaultValue  string
+		allowedValues []string
+		arguments     []string
+		expected      string
+		initErr       error
+		parseErr      error
+	}
+
+	tests := []testCase{
+		{
+			name:          "ignore case",
+			matching:      []flagenum.Normalizer{flagenum.IgnoreCase},
+			allowedValues: []string{"debug", "info"},
+			arguments:     []string{"INFO"},
+			expected:      "info",
+		},
+		{
+			name:          "trim space",
+			matching:      []flagenum.Normalizer{flagenum.TrimSpace},
+			allowedValues: []string{"debug", "info"},
+			arguments:     []string{" info "},
+			expected:      "info",
+		},
+		{
+			name:          "ignore case and trim space",
+			matching:      []flagenum.Normalizer{flagenum.IgnoreCase, flagenum.TrimSpace},
+			allowedValues: []string{"Debug", "Info"},
+			arguments:     []string{" iNFO"},
+			expected:      "Info",
+		},
+		{
+			name:          "custom normalizer",
+			matching:      []flagenum.Normalizer{func(s string) string { return strings.ReplaceAll(s, "_", "-") }},
+			allowedValues: []string{"log-level"},
+			arguments:     []string{"log_level"},
+			expected:      "log-level",
+		},
+		{
+			name:          "unicode composition",
+			matching:      []flagenum.Normalizer{unorm.NFC},
+			allowedValues: []string{"caf\u00e9", "bar"},
+			arguments:     []string{"cafe\u0301"},
+			expected:      "caf\u00e9",
+		},
+		{
+			name:          "unicode compatibility and ignore case",
+			matching:      []flagenum.Normalizer{unorm.NFKC, flagenum.IgnoreCase},
+			allowedValues: []string{"file", "dir"},
+			arguments:     []string{"\ufb01LE"},
+			expected:      "file",
+		},
+		{
+			name:          "canonical default",
+			matching:      []flagenum.Normalizer{flagenum.IgnoreCase},
+			defaultValue:  "INFO",
+			allowedValues: []string{"debug", "info"},
+			expected:      "info",
+		},
+		{
+			name:          "exact match by default",
+			allowedValues: []string{"debug", "info"},
+			arguments:     []string{"INFO"},
+			parseErr:      fmt.Errorf("invalid value \"INFO\" for flag -val: must be one of debug,info; did you mean \"info\"?"),
+		},
+		{
+			name:          "duplicated allowed",
+			matching:      []flagenum.Normalizer{flagenum.IgnoreCase},
+			allowedValues: []string{"Rest", "rest"},
+			initErr:       fmt.Errorf("duplicated allowed value \"rest\" for flag -val"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			selected, err := flagenum.Single(flag, "val", test.defaultValue, test.allowedValues, strAsIs, strAsIs, "enumerated parameter", flagenum.Matching(test.matching...))
+			if test.initErr != nil {
+				assert.EqualError(t, err, test.initErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+
+			a := []string{}
+			for _, arg := range test.arguments {
+				a = append(a, "--val", arg)
+			}
+			err = flag.Parse(a)
+			if test.parseErr != nil {
+				assert.EqualError(t, err, test.parseErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, *selected)
+			}
+		})
+	}
+}
+
+func Test_Multiple_Matching(t *testing.T) {
+	newFlag := func() (*flag.FlagSet, *[]string) {
+		flag := flag.NewFlagSet("test", flag.ContinueOnError)
+		selected, err := flagenum.Multiple(flag, "val", nil, []string{"rest", "grpc"}, strAsIs, strAsIs, "enumerated parameter", flagenum.Matching(flagenum.IgnoreCase))
+		assert.NoError(t, err)
+		return flag, selected
+	}
+
+	flag, selected := newFlag()
+	assert.NoError(t, flag.Parse([]string{"--val", "REST", "--val", "gRPC"}))
+	assert.Equal(t, []string{"rest", "grpc"}, *selected)
+
+	flag, _ = newFlag()
+	err := flag.Parse([]string{"--val", "rest", "--val", "Rest"})
+	assert.EqualError(t, err, "invalid value \"Rest\" for flag -val: duplicated value \"rest\" for flag -val")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -134,6 +135,102 @@ func Test_Multiple_String_Usage(t *testing.T) {
 	assert.Equal(t, "Usage of test:\n  -val any of v1,v2,v3\n    \tenumerated parameter (allowed any of v1,v2,v3) (default v1,v3)\n", out.String())
 }
 
+func Test_MultipleE_Duration(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	var selected []time.Duration
+	err := flagenum.MultipleVarE(flag, &selected, "val", []time.Duration{time.Second}, nil, time.ParseDuration, time.Duration.String, "enumerated parameter")
+	assert.NoError(t, err)
+
+	assert.NoError(t, flag.Parse([]string{"--val", "1m", "--val", "5s"}))
+	assert.Equal(t, []time.Duration{time.Minute, 5 * time.Second}, selected)
+
+	err = flag.Parse([]string{"--val", "5x"})
+	assert.EqualError(t, err, "invalid value \"5x\" for flag -val: time: unknown unit \"x\" in duration \"5x\"")
+}
+
+func Test_Multiple_Separator(t *testing.T) {
+	type testCase struct {
+		name      string
+		arguments []string
+		expected  []string
+		parseErr  error
+	}
+
+	tests := []testCase{
+		{
+			name:      "comma separated",
+			arguments: []string{"rest,grpc"},
+			expected:  []string{"rest", "grpc"},
+		},
+		{
+			name:      "comma separated and repeated",
+			arguments: []string{"rest,grpc", "soap"},
+			expected:  []string{"rest", "grpc", "soap"},
+		},
+		{
+			name:      "quoted",
+			arguments: []string{`"a,b",rest`, `"say ""hi"""`},
+			expected:  []string{"a,b", "rest", `say "hi"`},
+		},
+		{
+			name:      "empty",
+			arguments: []string{""},
+			expected:  nil,
+		},
+		{
+			name:      "bad element",
+			arguments: []string{"rest,http"},
+			parseErr:  fmt.Errorf("invalid value \"rest,http\" for flag -val: element \"http\": must be one of rest,grpc,soap,a,b,say \"hi\""),
+		},
+		{
+			name:      "duplicated element",
+			arguments: []string{"rest,grpc,rest"},
+			parseErr:  fmt.Errorf("invalid value \"rest,grpc,rest\" for flag -val: element \"rest\": duplicated value \"rest\" for flag -val"),
+		},
+		{
+			name:      "unclosed quote",
+			arguments: []string{`rest,"grpc`},
+			parseErr:  fmt.Errorf("invalid value \"rest,\\\"grpc\" for flag -val: missing closing quote"),
+		},
+		{
+			name:      "text after quote",
+			arguments: []string{`"rest"grpc`},
+			parseErr:  fmt.Errorf("invalid value \"\\\"rest\\\"grpc\" for flag -val: unexpected \"g\" after closing quote"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			selected, err := flagenum.Multiple(flag, "val", []string{"rest"}, []string{"rest", "grpc", "soap", "a,b", `say "hi"`}, strAsIs, strAsIs, "enumerated parameter", flagenum.Separator(','))
+			assert.NoError(t, err)
+
+			a := []string{}
+			for _, arg := range test.arguments {
+				a = append(a, "--val", arg)
+			}
+			err = flag.Parse(a)
+			if test.parseErr != nil {
+				assert.EqualError(t, err, test.parseErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, *selected)
+			}
+		})
+	}
+}
+
+func Test_Multiple_Separator_String(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	selected := flagenum.Wrap(flag).MultipleStrings("val", []string{"a;b", "c"}, nil, "enumerated parameter", flagenum.Separator(';'))
+
+	value := flag.Lookup("val").Value.String()
+	assert.Equal(t, `"a;b";c`, value)
+
+	assert.NoError(t, flag.Parse([]string{"--val", value}))
+	assert.Equal(t, []string{"a;b", "c"}, *selected)
+}
+
 func strAsIs(s string) string {
 	return s
 }
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Numeric(t *testing.T) {
+	type testCase struct {
+		name  string
+		args  []string
+		check func(t *testing.T, flags *flagenum.FlagSetExt) func()
+		err   string
+	}
+
+	tests := []testCase{
+		{
+			name: "int",
+			args: []string{"-workers", "0x10"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				v := flags.SingleInt("workers", 4, []int{4, 8, 16}, "workers")
+				return func() { assert.Equal(t, 16, *v) }
+			},
+		},
+		{
+			name: "int not allowed",
+			args: []string{"-workers", "5"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				flags.SingleInt("workers", 4, []int{4, 8, 16}, "workers")
+				return nil
+			},
+			err: "invalid value \"5\" for flag -workers: must be one of 4,8,16",
+		},
+		{
+			name: "int parse error",
+			args: []string{"-workers", "four"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				flags.SingleInt("workers", 4, nil, "workers")
+				return nil
+			},
+			err: "invalid value \"four\" for flag -workers: strconv.ParseInt: parsing \"four\": invalid syntax",
+		},
+		{
+			name: "int64 out of range",
+			args: []string{"-size", "9223372036854775808"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				flags.SingleInt64("size", 0, nil, "size")
+				return nil
+			},
+			err: "invalid value \"9223372036854775808\" for flag -size: strconv.ParseInt: parsing \"9223372036854775808\": value out of range",
+		},
+		{
+			name: "uints",
+			args: []string{"-port", "80", "-port", "443"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				v := flags.MultipleUints("port", nil, []uint{80, 443, 8080}, "ports")
+				return func() { assert.Equal(t, []uint{80, 443}, *v) }
+			},
+		},
+		{
+			name: "uint64 negative",
+			args: []string{"-id", "-1"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				flags.MultipleUint64s("id", nil, nil, "ids")
+				return nil
+			},
+			err: "invalid value \"-1\" for flag -id: strconv.ParseUint: parsing \"-1\": invalid syntax",
+		},
+		{
+			name: "float64",
+			args: []string{"-ratio", "0.50"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				v := flags.SingleFloat64("ratio", 1, []float64{0.5, 1}, "ratio")
+				return func() { assert.Equal(t, 0.5, *v) }
+			},
+		},
+		{
+			name: "durations",
+			args: []string{"-timeout", "1s,1m0s"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				v := flags.MultipleDurations("timeout", []time.Duration{time.Second}, []time.Duration{time.Second, time.Minute}, "timeouts",
+					flagenum.Separator(','))
+				return func() { assert.Equal(t, []time.Duration{time.Second, time.Minute}, *v) }
+			},
+		},
+		{
+			name: "duration parse error",
+			args: []string{"-timeout", "soon"},
+			check: func(t *testing.T, flags *flagenum.FlagSetExt) func() {
+				flags.SingleDuration("timeout", time.Second, nil, "timeout")
+				return nil
+			},
+			err: "invalid value \"soon\" for flag -timeout: time: invalid duration \"soon\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := flagenum.New("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			check := test.check(t, flags)
+
+			err := flags.FlagSet.Parse(test.args)
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			check()
+		})
+	}
+}
+
+func Test_Numeric_Usage(t *testing.T) {
+	flags := flagenum.New("test", flag.ContinueOnError)
+	flags.MultipleDurations("timeout", []time.Duration{time.Second}, []time.Duration{time.Second, 90 * time.Second}, "timeouts")
+	flags.SingleFloat64("ratio", 0.25, []float64{0.25, 1e-7}, "ratio")
+
+	assert.Equal(t, "timeouts (allowed `any of 1s,1m30s`)", flags.Lookup("timeout").Usage)
+	assert.Equal(t, "1s", flags.Lookup("timeout").DefValue)
+	assert.Equal(t, "ratio (allowed `one of 0.25,1e-07`)", flags.Lookup("ratio").Usage)
+	assert.Equal(t, "0.25", flags.Lookup("ratio").DefValue)
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Single_Patterns(t *testing.T) {
+	type testCase struct {
+		name     string
+		allowed  []string
+		opts     []flagenum.Option
+		value    string
+		expected string
+		usage    string
+		err      string
+	}
+
+	tests := []testCase{
+		{
+			name:     "exact value",
+			allowed:  []string{"us-east"},
+			opts:     []flagenum.Option{flagenum.Glob("eu-*")},
+			value:    "us-east",
+			expected: "us-east",
+			usage:    "region (allowed `one of us-east or matching eu-*`)",
+		},
+		{
+			name:     "glob",
+			allowed:  []string{"us-east"},
+			opts:     []flagenum.Option{flagenum.Glob("eu-*")},
+			value:    "eu-west",
+			expected: "eu-west",
+			usage:    "region (allowed `one of us-east or matching eu-*`)",
+		},
+		{
+			name:     "regexp",
+			opts:     []flagenum.Option{flagenum.Regexp(`^[a-z]+-v[0-9]+$`)},
+			value:    "backend-v2",
+			expected: "backend-v2",
+			usage:    "region (allowed `one matching /^[a-z]+-v[0-9]+$/`)",
+		},
+		{
+			name:     "normalized",
+			opts:     []flagenum.Option{flagenum.Glob("eu-*"), flagenum.Matching(flagenum.IgnoreCase)},
+			value:    "EU-North",
+			expected: "EU-North",
+			usage:    "region (allowed `one matching eu-*`)",
+		},
+		{
+			name:     "case-insensitive regexp",
+			opts:     []flagenum.Option{flagenum.Regexp(`(?i)^[a-z]+-v[0-9]+$`)},
+			value:    "Backend-V2",
+			expected: "Backend-V2",
+			usage:    "region (allowed `one matching /(?i)^[a-z]+-v[0-9]+$/`)",
+		},
+		{
+			name:    "not matched",
+			allowed: []string{"us-east"},
+			opts:    []flagenum.Option{flagenum.Glob("eu-*"), flagenum.Regexp(`^ap-\d$`)},
+			value:   "ap-south",
+			usage:   "region (allowed `one of us-east or matching eu-*,/^ap-\\d$/`)",
+			err:     "must be one of us-east or match eu-*,/^ap-\\d$/",
+		},
+		{
+			name:  "not matched patterns only",
+			opts:  []flagenum.Option{flagenum.Glob("eu-*")},
+			value: "us-east",
+			usage: "region (allowed `one matching eu-*`)",
+			err:   "must match eu-*",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			selected, err := flagenum.Single(flag, "region", "", test.allowed, strAsIs, strAsIs, "region", test.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, test.usage, flag.Lookup("region").Usage)
+
+			err = flag.Set("region", test.value)
+			if len(test.err) > 0 {
+				var notAllowed *flagenum.NotAllowedError
+				assert.True(t, errors.As(err, &notAllowed))
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, *selected)
+		})
+	}
+}
+
+func Test_Multiple_Patterns_Invalid(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err := flagenum.Multiple(flag, "region", nil, nil, strAsIs, strAsIs, "region", flagenum.Glob("eu-["))
+	assert.EqualError(t, err, "invalid glob pattern \"eu-[\" for flag -region: syntax error in pattern")
+
+	_, err = flagenum.Multiple(flag, "backend", nil, nil, strAsIs, strAsIs, "backend", flagenum.Regexp("v(1"))
+	assert.EqualError(t, err, "invalid regexp \"v(1\" for flag -backend: error parsing regexp: missing closing ): `v(1`")
+
+	_, err = flagenum.Multiple(flag, "zone", []string{"us-1"}, nil, strAsIs, strAsIs, "zone", flagenum.Glob("eu-*"))
+	assert.EqualError(t, err, "unexpected default value \"us-1\" for flag -zone: must match eu-*")
+}
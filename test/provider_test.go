@@ -0,0 +1,181 @@
+package test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func strAsIsE(s string) (string, error) { return s, nil }
+
+func Test_MultipleVarFunc(t *testing.T) {
+	calls := 0
+	plugins := func() ([]string, error) {
+		calls++
+		return []string{"auth", "cache", "gzip"}, nil
+	}
+
+	flags := flagenum.New("test", flag.ContinueOnError)
+	var selected []string
+	err := flagenum.MultipleVarFunc(flags.FlagSet, &selected, "plugin", []string{"Auth"}, plugins, strAsIsE, strAsIs, "enabled plugins",
+		flagenum.Matching(flagenum.IgnoreCase))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, "enabled plugins", flags.Lookup("plugin").Usage)
+	assert.Equal(t, []string{"Auth"}, selected)
+
+	out := &strings.Builder{}
+	flags.SetOutput(out)
+	flags.PrintDefaults()
+	assert.Equal(t, "  -plugin any of auth,cache,gzip\n    \tenabled plugins (allowed any of auth,cache,gzip) (default auth)\n", out.String())
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []string{"auth"}, selected)
+
+	assert.NoError(t, flags.Parse([]string{"--plugin", "gzip", "--plugin", "cache"}))
+	assert.Equal(t, []string{"gzip", "cache"}, selected)
+	assert.Equal(t, 1, calls)
+}
+
+func Test_SingleVarFunc(t *testing.T) {
+	type testCase struct {
+		name     string
+		provider func() ([]string, error)
+		value    string
+		expected string
+		err      string
+	}
+
+	tests := []testCase{
+		{
+			name:     "set",
+			provider: func() ([]string, error) { return []string{"h264", "vp9"}, nil },
+			value:    "vp9",
+			expected: "vp9",
+		},
+		{
+			name:     "provider error",
+			provider: func() ([]string, error) { return nil, errors.New("codecs unavailable") },
+			value:    "vp9",
+			err:      "invalid value \"vp9\" for flag -codec: allowed values of flag -codec: codecs unavailable",
+		},
+		{
+			name:     "invalid default",
+			provider: func() ([]string, error) { return []string{"vp9"}, nil },
+			value:    "vp9",
+			err: "invalid value \"vp9\" for flag -codec: " +
+				"unexpected default value \"h264\" for flag -codec: must be one of vp9",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := flagenum.New("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			var selected string
+			err := flagenum.SingleVarFunc(flags.FlagSet, &selected, "codec", "h264", test.provider, strAsIsE, strAsIs, "video codec")
+			assert.NoError(t, err)
+
+			err = flags.Parse([]string{"--codec", test.value})
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, selected)
+		})
+	}
+}
+
+func Test_SingleVarFunc_Usage_Error(t *testing.T) {
+	flags := flagenum.New("test", flag.ContinueOnError)
+	out := &strings.Builder{}
+	flags.SetOutput(out)
+	var selected string
+	err := flagenum.SingleVarFunc(flags.FlagSet, &selected, "codec", "", func() ([]string, error) {
+		return nil, errors.New("codecs unavailable")
+	}, strAsIsE, strAsIs, "video codec")
+	assert.NoError(t, err)
+
+	flags.PrintDefaults()
+	assert.Equal(t, "  -codec value\n    \tvideo codec (allowed values unavailable: allowed values of flag -codec: codecs unavailable)\n", out.String())
+}
+
+func Test_VarFunc_Unset_Flag(t *testing.T) {
+	type testCase struct {
+		name     string
+		define   func(flags *flagenum.FlagSetExt, provider func() ([]string, error)) error
+		provider func() ([]string, error)
+		err      string
+	}
+
+	single := func(flags *flagenum.FlagSetExt, provider func() ([]string, error)) error {
+		var selected string
+		return flagenum.SingleVarFunc(flags.FlagSet, &selected, "codec", "x", provider, strAsIsE, strAsIs, "video codec")
+	}
+	multiple := func(flags *flagenum.FlagSetExt, provider func() ([]string, error)) error {
+		var selected []string
+		return flagenum.MultipleVarFunc(flags.FlagSet, &selected, "codec", []string{"x"}, provider, strAsIsE, strAsIs, "video codecs")
+	}
+	invalidDefault := func() ([]string, error) { return []string{"a"}, nil }
+	unavailable := func() ([]string, error) { return nil, errors.New("codecs unavailable") }
+
+	tests := []testCase{
+		{
+			name:     "single invalid default",
+			define:   single,
+			provider: invalidDefault,
+			err:      "unexpected default value \"x\" for flag -codec: must be one of a",
+		},
+		{
+			name:     "multiple invalid default",
+			define:   multiple,
+			provider: invalidDefault,
+			err:      "unexpected default value \"x\" for flag -codec: must be one of a",
+		},
+		{
+			name:     "single provider error",
+			define:   single,
+			provider: unavailable,
+			err:      "allowed values of flag -codec: codecs unavailable",
+		},
+		{
+			name:     "multiple provider error",
+			define:   multiple,
+			provider: unavailable,
+			err:      "allowed values of flag -codec: codecs unavailable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := flagenum.New("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			assert.NoError(t, test.define(flags, test.provider))
+			assert.EqualError(t, flags.Parse(nil), test.err)
+		})
+	}
+}
+
+func Test_VarFunc_Unset_Flag_Without_Default(t *testing.T) {
+	calls := 0
+	unavailable := func() ([]string, error) {
+		calls++
+		return nil, errors.New("dir missing")
+	}
+
+	flags := flagenum.New("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var codec string
+	var codecs []string
+	assert.NoError(t, flagenum.SingleVarFunc(flags.FlagSet, &codec, "codec", "", unavailable, strAsIsE, strAsIs, "video codec"))
+	assert.NoError(t, flagenum.MultipleVarFunc(flags.FlagSet, &codecs, "codecs", nil, unavailable, strAsIsE, strAsIs, "video codecs"))
+
+	assert.NoError(t, flags.Parse(nil))
+	assert.Equal(t, 0, calls)
+}
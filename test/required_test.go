@@ -0,0 +1,72 @@
+package test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Required(t *testing.T) {
+	type testCase struct {
+		name string
+		env  string
+		args []string
+		err  string
+	}
+
+	tests := []testCase{
+		{
+			name: "all set",
+			args: []string{"--api", "rest", "--log-level", "info"},
+		},
+		{
+			name: "set by environment variable",
+			env:  "debug",
+			args: []string{"--api", "rest"},
+		},
+		{
+			name: "one missing",
+			args: []string{"--log-level", "info"},
+			err:  "missing required flag -api",
+		},
+		{
+			name: "all missing",
+			err:  "missing required flags -api, -log-level",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if len(test.env) > 0 {
+				t.Setenv("TEST_LOG_LEVEL", test.env)
+			}
+			flags := flagenum.New("test", flag.ContinueOnError)
+			out := &strings.Builder{}
+			flags.SetOutput(out)
+			flags.AutoEnv("TEST")
+			flags.MultipleStrings("api", []string{"rest"}, []string{"rest", "grpc"}, "enabled api engine", flagenum.Required())
+			flags.SingleString("log-level", "", []string{"debug", "info"}, "logger level", flagenum.Required())
+			flags.SingleString("mode", "", []string{"fast", "safe"}, "mode")
+
+			err := flags.Parse(test.args)
+			if len(test.err) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.err)
+			assert.Equal(t, test.err+`
+Usage of test:
+  -api any of rest,grpc
+    	enabled api engine (allowed any of rest,grpc) (required) (env TEST_API) (default rest)
+  -log-level one of debug,info
+    	logger level (allowed one of debug,info) (required) (env TEST_LOG_LEVEL)
+  -mode one of fast,safe
+    	mode (allowed one of fast,safe) (env TEST_MODE)
+`, out.String())
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Reset(t *testing.T) {
+	flags := flagenum.New("test", flag.ContinueOnError)
+	api := flags.MultipleStrings("api", []string{"rest"}, []string{"rest", "grpc", "soap"}, "enabled api engine")
+	level := flags.SingleString("log-level", "info", []string{"debug", "info"}, "logger level")
+	mode := flags.SingleString("mode", "", []string{"fast", "safe"}, "mode")
+
+	assert.NoError(t, flags.Parse([]string{"--api", "grpc", "--api", "soap", "--log-level", "debug", "--mode", "fast"}))
+	assert.Equal(t, []string{"grpc", "soap"}, *api)
+	assert.Equal(t, "debug", *level)
+	assert.Equal(t, "fast", *mode)
+
+	flags.Reset()
+	assert.Equal(t, []string{"rest"}, *api)
+	assert.Equal(t, "info", *level)
+	assert.Equal(t, "", *mode)
+
+	assert.NoError(t, flags.Parse([]string{"--api", "soap", "--api", "grpc"}))
+	assert.Equal(t, []string{"soap", "grpc"}, *api)
+	assert.Equal(t, "info", *level)
+
+	flags.Reset()
+	assert.NoError(t, flags.Parse(nil))
+	assert.Equal(t, []string{"rest"}, *api)
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Rules(t *testing.T) {
+	type testCase struct {
+		name string
+		args []string
+		api  []string
+		err  string
+	}
+
+	tests := []testCase{
+		{
+			name: "no rule applied",
+			args: []string{"--log-format", "json"},
+			api:  []string{"rest"},
+		},
+		{
+			name: "required flag set",
+			args: []string{"--api", "soap", "--wsdl", "service.wsdl"},
+			api:  []string{"soap"},
+		},
+		{
+			name: "implied value",
+			args: []string{"--mode", "replica"},
+			api:  []string{"grpc"},
+		},
+		{
+			name: "implied value given",
+			args: []string{"--mode", "REPLICA", "--api", "grpc", "--api", "rest"},
+			api:  []string{"grpc", "rest"},
+		},
+		{
+			name: "all violations",
+			args: []string{"--api", "soap", "--log-format", "json", "--color", "always", "--mode", "replica"},
+			err: "-api soap requires -wsdl\n" +
+				"-log-format json conflicts with -color always\n" +
+				"-mode replica implies -api grpc",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := flagenum.New("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			api := flags.MultipleStrings("api", []string{"rest"}, []string{"rest", "grpc", "soap"}, "enabled api engine")
+			flags.String("wsdl", "", "WSDL file")
+			flags.SingleString("log-format", "text", []string{"text", "json"}, "log format")
+			flags.SingleString("color", "auto", []string{"auto", "always", "never"}, "colored output")
+			flags.SingleString("mode", "", []string{"primary", "replica"}, "server mode", flagenum.Matching(flagenum.IgnoreCase))
+
+			flags.Requires(flagenum.Is("api", "soap"), flagenum.IsSet("wsdl"))
+			flags.Conflicts(flagenum.Is("log-format", "json"), flagenum.Is("color", "always"))
+			flags.Implies(flagenum.Is("mode", "replica"), flagenum.Is("api", "grpc"))
+
+			err := flags.Parse(test.args)
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.api, *api)
+		})
+	}
+}
+
+func Test_Rules_Usage(t *testing.T) {
+	flags := flagenum.New("test", flag.ContinueOnError)
+	out := &strings.Builder{}
+	flags.SetOutput(out)
+	flags.SingleString("log-format", "text", []string{"text", "json"}, "log format")
+	flags.Bool("color", false, "colored output")
+	flags.Conflicts(flagenum.Is("log-format", "json"), flagenum.IsSet("color"))
+	flags.Requires(flagenum.IsSet("color"), flagenum.Is("undefined", "x"))
+
+	flags.PrintDefaults()
+	assert.Equal(t, `  -color
+    	colored output
+  -log-format one of text,json
+    	log format (allowed one of text,json) (default text)
+Rules:
+  -log-format json conflicts with -color
+  -color requires -undefined x
+`, out.String())
+
+	out.Reset()
+	err := flags.Parse([]string{"-color"})
+	assert.EqualError(t, err, "undefined flag -undefined of rule condition -undefined x")
+}
+
+func Test_Rules_Reset(t *testing.T) {
+	flags := flagenum.New("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.MultipleStrings("api", []string{"rest"}, []string{"rest", "grpc", "soap"}, "enabled api engine")
+	flags.String("wsdl", "", "WSDL file")
+	flags.Bool("verbose", false, "verbose output")
+	flags.Requires(flagenum.Is("api", "soap"), flagenum.IsSet("wsdl"))
+
+	assert.NoError(t, flags.Parse([]string{"--api", "soap", "-verbose", "--wsdl=service.wsdl"}))
+
+	flags.Reset()
+	assert.EqualError(t, flags.Parse([]string{"--api", "soap", "-verbose", "--", "--wsdl", "service.wsdl"}), "-api soap requires -wsdl")
+
+	flags.Reset()
+	t.Setenv("WSDL", "service.wsdl")
+	flags.BindEnv("wsdl", "WSDL")
+	assert.NoError(t, flags.Parse([]string{"--api", "soap"}))
+}
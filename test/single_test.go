@@ -3,6 +3,7 @@ package test
 import (
 	"flag"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -112,3 +113,81 @@ func Test_Single_String_Usage(t *testing.T) {
 
 	assert.Equal(t, "Usage of test:\n  -val one of v1,v2\n    \tenumerated parameter (allowed one of v1,v2) (default v1)\n", out.String())
 }
+
+func Test_SingleE_Int(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	selected, err := flagenum.SingleE(flag, "val", 1, []int{1, 2, 3}, strconv.Atoi, strconv.Itoa, "enumerated parameter")
+	assert.NoError(t, err)
+
+	assert.NoError(t, flag.Parse([]string{"--val", "3"}))
+	assert.Equal(t, 3, *selected)
+
+	err = flag.Parse([]string{"--val", "three"})
+	assert.EqualError(t, err, "invalid value \"three\" for flag -val: strconv.Atoi: parsing \"three\": invalid syntax")
+
+	err = flag.Parse([]string{"--val", "4"})
+	assert.EqualError(t, err, "invalid value \"4\" for flag -val: must be one of 1,2,3")
+}
+
+func Test_Single_Struct(t *testing.T) {
+	type endpoint struct {
+		host string
+		port int
+	}
+	var (
+		local  = endpoint{"localhost", 8080}
+		remote = endpoint{"example.com", 443}
+		toStr  = func(e endpoint) string { return e.host + ":" + strconv.Itoa(e.port) }
+		toV    = func(s string) (endpoint, error) {
+			host, port, _ := strings.Cut(s, ":")
+			p, err := strconv.Atoi(port)
+			return endpoint{host, p}, err
+		}
+	)
+
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	selected, err := flagenum.SingleE(flag, "val", local, []endpoint{local, remote}, toV, toStr, "enumerated parameter")
+	assert.NoError(t, err)
+
+	assert.NoError(t, flag.Parse([]string{"--val", "example.com:443"}))
+	assert.Equal(t, remote, *selected)
+
+	err = flag.Parse([]string{"--val", "example.com:80"})
+	assert.EqualError(t, err, "invalid value \"example.com:80\" for flag -val: must be one of localhost:8080,example.com:443; did you mean \"example.com:443\"?")
+}
+
+func Test_Single_Bool(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	selected, err := flagenum.SingleE(flag, "val", false, []bool{true, false}, strconv.ParseBool, strconv.FormatBool, "enumerated parameter")
+	assert.NoError(t, err)
+
+	assert.NoError(t, flag.Parse([]string{"--val", "true"}))
+	assert.Equal(t, true, *selected)
+}
+
+func Test_Single_Multiple_Only_Options(t *testing.T) {
+	type testCase struct {
+		name string
+		opts []flagenum.Option
+		err  string
+	}
+
+	tests := []testCase{
+		{name: "separator", opts: []flagenum.Option{flagenum.Separator(',')}, err: "option Separator is not applicable to single flag -val"},
+		{name: "incremental", opts: []flagenum.Option{flagenum.Incremental()}, err: "option Incremental is not applicable to single flag -val"},
+		{
+			name: "several",
+			opts: []flagenum.Option{flagenum.Separator(','), flagenum.MinCount(3), flagenum.MaxCount(4), flagenum.AllToken("all"), flagenum.NoneToken("none")},
+			err:  "options Separator, MinCount, MaxCount, AllToken, NoneToken are not applicable to single flag -val",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := flagenum.Single(flag, "val", "first", []string{"first", "second"}, strAsIs, strAsIs, "value", test.opts...)
+			assert.EqualError(t, err, test.err)
+			assert.Nil(t, flag.Lookup("val"))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+type Engine int
+
+const (
+	Rest Engine = iota
+	Grpc
+	Soap
+)
+
+func (e Engine) String() string {
+	switch e {
+	case Rest:
+		return "rest"
+	case Grpc:
+		return "grpc"
+	case Soap:
+		return "soap"
+	}
+	return "engine"
+}
+
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+)
+
+func (l Level) String() string {
+	if l == Debug {
+		return "debug"
+	}
+	return "info"
+}
+
+func (l *Level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug", "d":
+		*l = Debug
+	case "info", "i":
+		*l = Info
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+func Test_MultipleOf(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	engines, err := flagenum.MultipleOf(flag, "api", []Engine{Rest}, []Engine{Rest, Grpc, Soap}, "enabled api engine")
+	assert.NoError(t, err)
+	assert.Equal(t, "enabled api engine (allowed `any of rest,grpc,soap`)", flag.Lookup("api").Usage)
+	assert.Equal(t, "rest", flag.Lookup("api").DefValue)
+
+	assert.NoError(t, flag.Parse([]string{"--api", "grpc", "--api", "soap"}))
+	assert.Equal(t, []Engine{Grpc, Soap}, *engines)
+
+	err = flag.Set("api", "graphql")
+	var notAllowed *flagenum.NotAllowedError
+	assert.True(t, errors.As(err, &notAllowed))
+	assert.EqualError(t, err, "must be one of rest,grpc,soap")
+}
+
+func Test_MultipleOf_Duplicated(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err := flagenum.MultipleOf(flag, "api", nil, []Engine{Rest, Engine(10), Engine(11)}, "enabled api engine")
+	assert.EqualError(t, err, "duplicated allowed value \"engine\" for flag -api")
+
+	_, err = flagenum.SingleOf(flag, "engine", Rest, []Engine{Rest, Engine(10), Engine(11)}, "enabled api engine",
+		flagenum.Duplicates(flagenum.DuplicateIgnore))
+	assert.EqualError(t, err, "duplicated allowed value \"engine\" for flag -engine")
+
+	_, err = flagenum.SingleOf(flag, "engine", Rest, []Engine{Rest, Grpc, Rest}, "enabled api engine",
+		flagenum.Duplicates(flagenum.DuplicateIgnore))
+	assert.NoError(t, err)
+
+	_, err = flagenum.MultipleOf(flag, "engines", nil, []Engine(nil), "enabled api engine")
+	assert.EqualError(t, err, "flag -engines of type test.Engine requires allowed values or the encoding.TextUnmarshaler implementation")
+}
+
+func Test_SingleOf_TextUnmarshaler(t *testing.T) {
+	type testCase struct {
+		name     string
+		allowed  []Level
+		value    string
+		expected Level
+		err      string
+	}
+
+	tests := []testCase{
+		{name: "by string", allowed: []Level{Debug, Info}, value: "debug", expected: Debug},
+		{name: "by unmarshal", allowed: []Level{Debug, Info}, value: "I", expected: Info},
+		{name: "any by unmarshal", value: "d", expected: Debug},
+		{name: "unmarshal error", value: "warn", err: "invalid value \"warn\" for flag -log-level: unknown level"},
+		{name: "not allowed", allowed: []Level{Info}, value: "d", err: "invalid value \"d\" for flag -log-level: must be one of info"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			var level Level
+			err := flagenum.SingleVarOf(flag, &level, "log-level", Info, test.allowed, "logger level")
+			assert.NoError(t, err)
+
+			err = flag.Parse([]string{"--log-level", test.value})
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, level)
+		})
+	}
+}
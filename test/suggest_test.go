@@ -0,0 +1,106 @@
+package test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Suggestions(t *testing.T) {
+	allowed := []string{"rest", "grpc", "soap", "graphql"}
+	type testCase struct {
+		name        string
+		argument    string
+		suggestions []string
+		message     string
+	}
+
+	tests := []testCase{
+		{
+			name:        "transposition",
+			argument:    "grcp",
+			suggestions: []string{"grpc"},
+			message:     "invalid value \"grcp\" for flag -val: must be one of rest,grpc,soap,graphql; did you mean \"grpc\"?",
+		},
+		{
+			name:        "letter case first",
+			argument:    "GRAPHQL",
+			suggestions: []string{"graphql"},
+		},
+		{
+			name:        "ranked by distance",
+			argument:    "grapql",
+			suggestions: []string{"graphql"},
+		},
+		{
+			name:     "nothing close",
+			argument: "websocket",
+			message:  "invalid value \"websocket\" for flag -val: must be one of rest,grpc,soap,graphql",
+		},
+		{
+			name:        "alias",
+			argument:    "htpt",
+			suggestions: []string{"rest"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := flagenum.Single(flag, "val", "", allowed, strAsIs, strAsIs, "", flagenum.Aliases(map[string]string{"http": "rest"}))
+			assert.NoError(t, err)
+
+			err = flag.Set("val", test.argument)
+
+			var notAllowed *flagenum.NotAllowedError
+			if assert.True(t, errors.As(err, &notAllowed)) {
+				assert.Equal(t, test.argument, notAllowed.Value)
+				assert.Equal(t, allowed, notAllowed.Allowed)
+				assert.Equal(t, test.suggestions, notAllowed.Suggestions)
+			}
+			if len(test.message) > 0 {
+				assert.EqualError(t, flag.Parse([]string{"--val", test.argument}), test.message)
+			}
+		})
+	}
+}
+
+func Test_Suggestions_Default(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err := flagenum.Single(flag, "val", "inf", []string{"debug", "info"}, strAsIs, strAsIs, "")
+	assert.EqualError(t, err, "unexpected default value \"inf\" for flag -val: must be one of debug,info; did you mean \"info\"?")
+
+	var notAllowed *flagenum.NotAllowedError
+	assert.True(t, errors.As(err, &notAllowed))
+	assert.Equal(t, []string{"info"}, notAllowed.Suggestions)
+}
+
+func Test_Suggestions_Parse(t *testing.T) {
+	flags := flagenum.New("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.SingleString("lvl", "info", []string{"debug", "info", "warn"}, "logger level")
+	flags.MultipleStrings("api", nil, []string{"rest", "grpc"}, "enabled api engine", flagenum.Separator(','))
+
+	err := flags.Parse([]string{"-lvl", "inf"})
+	assert.EqualError(t, err, "invalid value \"inf\" for flag -lvl: must be one of debug,info,warn; did you mean \"info\"?")
+	var notAllowed *flagenum.NotAllowedError
+	if assert.True(t, errors.As(err, &notAllowed)) {
+		assert.Equal(t, []string{"info"}, notAllowed.Suggestions)
+	}
+
+	flags.Reset()
+	err = flags.Parse([]string{"-api", "rest,grcp"})
+	assert.EqualError(t, err, "invalid value \"rest,grcp\" for flag -api: element \"grcp\": must be one of rest,grpc; did you mean \"grpc\"?")
+	if assert.True(t, errors.As(err, &notAllowed)) {
+		assert.Equal(t, "grcp", notAllowed.Value)
+	}
+
+	flags.Reset()
+	err = flags.Parse([]string{"-undefined"})
+	assert.False(t, errors.As(err, &notAllowed))
+}
@@ -0,0 +1,72 @@
+# bash completion for app
+
+_app_complete() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" prev="" last=$((COMP_CWORD - 1))
+    if [ "$COMP_CWORD" -gt 0 ]; then
+        prev="${COMP_WORDS[COMP_CWORD-1]}"
+    fi
+    if [ "$cur" = "=" ]; then
+        cur=""
+    elif [ "$prev" = "=" ] && [ "$COMP_CWORD" -gt 1 ]; then
+        last=$((COMP_CWORD - 2))
+        prev="${COMP_WORDS[last]}"
+    fi
+    local flag="" values="" multiple=0
+    case "$prev" in
+    -*)
+        flag="${prev#-}"
+        flag="${flag#-}"
+        ;;
+    esac
+    case "$flag" in
+    'api')
+        values='rest
+grpc
+soap'
+        multiple=1
+        ;;
+    'config')
+        COMPREPLY=()
+        return
+        ;;
+    'log-level')
+        values='debug
+info
+warn'
+        ;;
+    *)
+        flag=""
+        ;;
+    esac
+    if [ -z "$flag" ]; then
+        case "$cur" in
+        -*)
+            local flags='-api -config -log-level -verbose'
+            case "$cur" in
+            -|--*)
+                flags='--api --config --log-level --verbose'
+                ;;
+            esac
+            COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+            ;;
+        esac
+        return
+    fi
+    if [ "$multiple" = 1 ]; then
+        local i word given
+        for ((i = 1; i < last; i++)); do
+            word="${COMP_WORDS[i]}"
+            if [ "$word" = "-$flag" ] || [ "$word" = "--$flag" ]; then
+                given="${COMP_WORDS[i+1]}"
+                if [ "$given" = "=" ]; then
+                    given="${COMP_WORDS[i+2]}"
+                fi
+                values="$(printf '%s\n' "$values" | grep -vxF -- "$given")"
+            fi
+        done
+    fi
+    local IFS=$'\n'
+    COMPREPLY=($(compgen -W "$values" -- "$cur"))
+}
+
+complete -o default -F _app_complete 'app'
@@ -0,0 +1,36 @@
+# fish completion for app
+
+function __app_flag_values
+    set -l flag $argv[1]
+    set -l given
+    if test "$argv[2]" = multiple
+        set -l tokens (commandline -opc)
+        for i in (seq 2 (count $tokens))
+            switch $tokens[$i]
+                case -$flag --$flag
+                    set -l next (math $i + 1)
+                    if test $next -le (count $tokens)
+                        set -a given $tokens[$next]
+                    end
+                case "-$flag=*" "--$flag=*"
+                    set -a given (string split -m 1 = -- $tokens[$i])[2]
+            end
+        end
+    end
+    set -l values
+    switch $flag
+        case 'api'
+            set values 'rest'\t'REST over HTTP' 'grpc'\t'gRPC engine' 'soap'
+        case 'log-level'
+            set values 'debug' 'info' 'warn'
+    end
+    for value in $values
+        set -l name (string split -m 1 \t -- $value)[1]
+        contains -- $name $given; or printf '%s\n' $value
+    end
+end
+
+complete -c 'app' -o 'api' -l 'api' -d 'enabled api engine' -x -a '(__app_flag_values \'api\' multiple)'
+complete -c 'app' -o 'config' -l 'config' -d 'config file' -r
+complete -c 'app' -o 'log-level' -l 'log-level' -d 'logger level' -x -a '(__app_flag_values \'log-level\' single)'
+complete -c 'app' -o 'verbose' -l 'verbose' -d 'verbose output'
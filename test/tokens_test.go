@@ -0,0 +1,141 @@
+package test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Multiple_Tokens(t *testing.T) {
+	type testCase struct {
+		name     string
+		args     []string
+		expected []string
+		err      string
+	}
+
+	tests := []testCase{
+		{
+			name:     "all",
+			args:     []string{"--api", "ALL"},
+			expected: []string{"rest", "grpc", "soap"},
+		},
+		{
+			name:     "none",
+			args:     []string{"--api", "none"},
+			expected: []string{},
+		},
+		{
+			name:     "all replaces previous",
+			args:     []string{"--api", "soap", "--api", "all"},
+			expected: []string{"rest", "grpc", "soap"},
+		},
+		{
+			name:     "none then values",
+			args:     []string{"--api", "none,grpc"},
+			expected: []string{"grpc"},
+		},
+		{
+			name:     "all then remove",
+			args:     []string{"--api", "all", "--api", "-grpc"},
+			expected: []string{"rest", "soap"},
+		},
+		{
+			name:     "all then selected value",
+			args:     []string{"--api", "all,rest", "--api", "grpc"},
+			expected: []string{"rest", "grpc", "soap"},
+		},
+		{
+			name:     "all, remove then add again",
+			args:     []string{"--api", "all,-rest,+rest"},
+			expected: []string{"grpc", "soap", "rest"},
+		},
+		{
+			name: "none then duplicate",
+			args: []string{"--api", "none,rest,rest"},
+			err:  "invalid value \"none,rest,rest\" for flag -api: element \"rest\": duplicated value \"rest\" for flag -api",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			var selected []string
+			err := flagenum.MultipleVar(flag, &selected, "api", []string{"rest"}, []string{"rest", "grpc", "soap"}, strAsIs, strAsIs,
+				"enabled api engine", flagenum.AllToken("all"), flagenum.NoneToken("none"), flagenum.Separator(','),
+				flagenum.Incremental(), flagenum.Matching(flagenum.IgnoreCase))
+			assert.NoError(t, err)
+			assert.Equal(t, "enabled api engine (allowed `any of rest,grpc,soap`) (all selects all values, none selects none) "+
+				"(+value adds to defaults, -value removes)", flag.Lookup("api").Usage)
+
+			err = flag.Parse(test.args)
+			if len(test.err) > 0 {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, selected)
+		})
+	}
+}
+
+func Test_Multiple_Tokens_Collision(t *testing.T) {
+	type testCase struct {
+		name    string
+		allowed []string
+		opts    []flagenum.Option
+		err     string
+	}
+
+	tests := []testCase{
+		{
+			name:    "allowed value",
+			allowed: []string{"rest", "None"},
+			opts:    []flagenum.Option{flagenum.NoneToken("none"), flagenum.Matching(flagenum.IgnoreCase)},
+			err:     "allowed value \"None\" of flag -api collides with reserved token \"none\"",
+		},
+		{
+			name:    "alias",
+			allowed: []string{"rest", "grpc"},
+			opts:    []flagenum.Option{flagenum.AllToken("all"), flagenum.Aliases(map[string]string{"all": "rest"})},
+			err:     "allowed value \"rest\" of flag -api collides with reserved token \"all\"",
+		},
+		{
+			name: "no allowed values",
+			opts: []flagenum.Option{flagenum.AllToken("all")},
+			err:  "token \"all\" of flag -api requires allowed values",
+		},
+		{
+			name:    "same tokens",
+			allowed: []string{"rest"},
+			opts:    []flagenum.Option{flagenum.AllToken("any"), flagenum.NoneToken("any")},
+			err:     "duplicated token \"any\" for flag -api",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := flagenum.Multiple(flag, "api", nil, test.allowed, strAsIs, strAsIs, "enabled api engine", test.opts...)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func Test_Multiple_Tokens_Selected_Keep(t *testing.T) {
+	t.Setenv("API", "all,grpc")
+	flags := flagenum.New("test", flag.ContinueOnError)
+	api := flags.MultipleStrings("api", nil, []string{"rest", "grpc"}, "enabled api engine",
+		flagenum.AllToken("all"), flagenum.Duplicates(flagenum.DuplicateKeep))
+	flags.BindEnv("api", "API")
+
+	assert.NoError(t, flags.Parse(nil))
+	assert.Equal(t, []string{"rest", "grpc"}, *api)
+
+	flags.Reset()
+	assert.NoError(t, flags.Parse([]string{"--api", "all", "--api", "rest"}))
+	assert.Equal(t, []string{"rest", "grpc"}, *api)
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m4gshm/flag/flagenum"
+)
+
+func Test_Descriptions_Usage(t *testing.T) {
+	out := &strings.Builder{}
+	flags := flagenum.New("test", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.MultipleStrings("api", []string{"rest", "grpc"}, []string{"rest", "grpc", "soap"}, "enabled api engine",
+		flagenum.Descriptions(map[string]string{"rest": "REST over HTTP", "grpc": "gRPC engine", "soap": "legacy SOAP engine"}))
+	flags.SingleString("log-level", "info", []string{"debug", "info", "warning"}, "logger level",
+		flagenum.Descriptions(map[string]string{"debug": "verbose output"}), flagenum.Aliases(map[string]string{"warn": "warning"}))
+	flags.SingleString("mode", "", []string{"primary", "replica"}, "replication mode")
+	flags.String("name", "app", "application name")
+
+	flags.Usage()
+
+	assert.Equal(t, `Usage of test:
+  -api value
+    	enabled api engine (allowed any of the values below) (default rest,grpc)
+    	  rest  REST over HTTP (default)
+    	  grpc  gRPC engine (default)
+    	  soap  legacy SOAP engine
+  -log-level value
+    	logger level (allowed one of the values below) (default info)
+    	  debug         verbose output
+    	  info          (default)
+    	  warning|warn
+  -mode one of primary,replica
+    	replication mode (allowed one of primary,replica)
+  -name string
+    	application name (default "app")
+`, out.String())
+}
+
+func Test_Descriptions_Errors(t *testing.T) {
+	flag := flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err := flagenum.Single(flag, "val", "", []string{"info"}, strAsIs, strAsIs, "", flagenum.Descriptions(map[string]string{"debug": "verbose"}))
+	assert.EqualError(t, err, "unexpected described value \"debug\" for flag -val: must be one of info")
+}